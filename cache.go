@@ -0,0 +1,97 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+	"watchtower/config"
+	"watchtower/intel"
+	"watchtower/paths"
+)
+
+// regenerableFiles are the cache files pruning may delete outright; the
+// next refresh writes them again. The histories are trimmed instead, and
+// files watchtower didn't write are neither counted nor touched.
+var regenerableFiles = map[string]bool{
+	"brief.json":        true,
+	"local_brief.json":  true,
+	"safety_brief.json": true,
+	"render-panics.log": true,
+}
+
+// The histories intel and markets keep next to the caches
+const (
+	riskHistoryFile  = "risk_history.csv"
+	priceHistoryFile = "price_history.json"
+)
+
+// pruneCache keeps the data directory within cache_max_age_days and
+// cache_max_mb: regenerable files untouched for longer than the age limit
+// are deleted, then the least recently written ones go until the rest fits
+// the size cap. If the risk history alone still breaks the cap, its oldest
+// rows are dropped. The price history isn't pruned; price_history_size
+// already bounds it.
+// It runs once at startup; failures are ignored, since a cache that can't be
+// pruned is no reason not to start.
+func pruneCache(cfg *config.Config) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	type entry struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []entry
+	var total int64 // watchtower's files, histories included
+	var riskSize int64
+	filepath.WalkDir(paths.CacheDir(home), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		switch {
+		case regenerableFiles[d.Name()]:
+			files = append(files, entry{path, info.Size(), info.ModTime()})
+		case d.Name() == riskHistoryFile:
+			riskSize = info.Size()
+			total += info.Size()
+		case d.Name() == priceHistoryFile:
+			total += info.Size()
+		}
+		return nil
+	})
+
+	kept := files[:0]
+	for _, f := range files {
+		if cfg.CacheMaxAgeDays > 0 && time.Since(f.modTime) > time.Duration(cfg.CacheMaxAgeDays)*24*time.Hour {
+			os.Remove(f.path)
+			continue
+		}
+		kept = append(kept, f)
+		total += f.size
+	}
+
+	if cfg.CacheMaxMB <= 0 {
+		return
+	}
+	limit := int64(cfg.CacheMaxMB) << 20
+	sort.Slice(kept, func(i, j int) bool { return kept[i].modTime.Before(kept[j].modTime) })
+	for _, f := range kept {
+		if total <= limit {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			total -= f.size
+		}
+	}
+	if total > limit && riskSize > 0 {
+		intel.TrimRiskHistory(riskSize - (total - limit))
+	}
+}
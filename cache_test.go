@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"watchtower/config"
+	"watchtower/paths"
+)
+
+func TestPruneCache(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := paths.CacheDir(home)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name string, size int, age time.Duration) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		mod := time.Now().Add(-age)
+		os.Chtimes(path, mod, mod)
+		return path
+	}
+
+	// 1 MiB cap: the old brief goes by age, the local brief by size, and
+	// the history then loses its oldest rows to fit
+	brief := write("brief.json", 10, 400*24*time.Hour)
+	local := write("local_brief.json", 300<<10, time.Hour)
+	prices := write("price_history.json", 100<<10, time.Hour)
+	mine := write("notes.txt", 2<<20, 400*24*time.Hour)
+	row := "2024-03-01T12:00:00Z,Ukraine,80\n"
+	var csv strings.Builder
+	csv.WriteString("timestamp,country,score\n")
+	for i := 0; csv.Len() < 1<<20; i++ {
+		csv.WriteString(time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC).Format(time.RFC3339) + row[len("2024-03-01T12:00:00Z"):])
+	}
+	history := filepath.Join(dir, riskHistoryFile)
+	os.WriteFile(history, []byte(csv.String()), 0644)
+
+	pruneCache(&config.Config{CacheMaxAgeDays: 180, CacheMaxMB: 1})
+
+	for _, gone := range []string{brief, local} {
+		if _, err := os.Stat(gone); !os.IsNotExist(err) {
+			t.Errorf("%s should have been pruned", filepath.Base(gone))
+		}
+	}
+	for _, kept := range []string{prices, mine, history} {
+		if _, err := os.Stat(kept); err != nil {
+			t.Errorf("%s should have been kept: %v", filepath.Base(kept), err)
+		}
+	}
+	hist, _ := os.Stat(history)
+	price, _ := os.Stat(prices)
+	if hist.Size()+price.Size() > 1<<20 {
+		t.Errorf("history %d + prices %d bytes still over the 1 MiB cap", hist.Size(), price.Size())
+	}
+	data, _ := os.ReadFile(history)
+	if !strings.HasPrefix(string(data), "timestamp,country,score\n2024-01-01T") || !strings.HasSuffix(string(data), ",Ukraine,80\n") {
+		t.Errorf("history isn't the newest whole rows: %.80q…", data)
+	}
+}
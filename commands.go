@@ -0,0 +1,366 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"watchtower/config"
+	"watchtower/feeds"
+	"watchtower/intel"
+	"watchtower/markets"
+	"watchtower/report"
+	"watchtower/ui"
+	"watchtower/weather"
+
+	"github.com/atotto/clipboard"
+)
+
+// loadConfigOrExit loads the config for one-shot subcommands, exiting with
+// a readable error if it is missing or broken.
+func loadConfigOrExit() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// loadDataDir loads the config, if there is one, for subcommands that only
+// need to know where data_dir puts the caches. A broken or missing config
+// leaves the default directory.
+func loadDataDir() {
+	if !config.ConfigExists() {
+		return
+	}
+	if _, err := config.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using ~/.cache/watchtower\n", err)
+	}
+}
+
+// runBrief implements `watchtower brief [--dry-run]`. With --dry-run it
+// prints the prompt that would be sent instead of calling the LLM.
+func runBrief(args []string) {
+	fs := flag.NewFlagSet("brief", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "print the prompt without calling the LLM")
+	fs.Parse(args)
+
+	cfg := loadConfigOrExit()
+	ctx := context.Background()
+
+	items, _, err := feeds.FetchGlobalNews(ctx, ui.FeedOptions(cfg))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching news: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := ui.BriefOptions(cfg)
+	if cfg.BriefWeather {
+		if loc, err := config.ResolveLocation(ctx, cfg.Location); err == nil {
+			opts.Weather, _, _ = weather.Fetch(ctx, loc.Latitude, loc.Longitude, loc.City, cfg.WeatherProvider)
+		}
+	}
+
+	if *dryRun {
+		fmt.Println(intel.BuildBriefPrompt(items, opts))
+		return
+	}
+
+	b, err := intel.GenerateBrief(ctx, ui.LLMConfig(cfg), items, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating brief: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("SUMMARY (%s, %s)\n%s\n", b.Model, b.GeneratedAt.Format("2006-01-02 15:04"), b.Summary)
+	if len(b.KeyThreats) > 0 {
+		fmt.Println("\nTHREATS")
+		for _, t := range b.KeyThreats {
+			fmt.Println("• " + t)
+		}
+	}
+	if len(b.CountryRisks) > 0 {
+		fmt.Println("\nCOUNTRY RISKS")
+		for _, cr := range b.CountryRisks {
+			fmt.Printf("%-20s %3d  %s\n", cr.Country, cr.Score, strings.TrimSpace(cr.Reason))
+		}
+	}
+}
+
+// runSnapshot implements `watchtower snapshot [-o file] [--copy]`: it fetches
+// the overview data once and prints it as Markdown.
+func runSnapshot(args []string) {
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	out := fs.String("o", "", "write the snapshot to this file instead of stdout")
+	copyIt := fs.Bool("copy", false, "copy the snapshot to the clipboard")
+	fs.Parse(args)
+
+	cfg := loadConfigOrExit()
+	snap := fetchSnapshot(context.Background(), cfg)
+	md := report.Markdown(snap)
+
+	switch {
+	case *copyIt:
+		if err := clipboard.WriteAll(md); err != nil {
+			fmt.Fprintf(os.Stderr, "Error copying to clipboard: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Snapshot copied to clipboard")
+	case *out != "":
+		if err := os.WriteFile(*out, []byte(md), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing snapshot: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Snapshot written to " + *out)
+	default:
+		fmt.Print(md)
+	}
+}
+
+// fetchSnapshot runs every overview fetcher concurrently. Failed sections
+// are left empty so a partial snapshot is still produced.
+func fetchSnapshot(ctx context.Context, cfg *config.Config) report.Snapshot {
+	snap := report.Snapshot{
+		TakenAt:  time.Now(),
+		City:     cfg.Location.City,
+		TempUnit: cfg.TempUnit,
+		WindUnit: cfg.WindUnit,
+	}
+	var wg sync.WaitGroup
+	run := func(f func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f()
+		}()
+	}
+
+	run(func() { snap.Crypto, _ = markets.FetchCryptoPrices(ctx, ui.CryptoOptions(cfg), cfg.CryptoPairs) })
+	run(func() { snap.Indices, _ = markets.FetchStockIndices(ctx) })
+	run(func() { snap.Commodities, _ = markets.FetchCommodities(ctx, ui.CommodityOptions(cfg)) })
+	run(func() {
+		loc, err := config.ResolveLocation(ctx, cfg.Location)
+		if err != nil {
+			return
+		}
+		snap.Weather, snap.Forecast, _ = weather.Fetch(ctx, loc.Latitude, loc.Longitude, loc.City, cfg.WeatherProvider)
+	})
+	run(func() { snap.Brief = loadOrGenerateBrief(ctx, cfg, nil) })
+
+	wg.Wait()
+	return snap
+}
+
+// loadOrGenerateBrief prefers a brief from the disk cache and only calls
+// the LLM when none is fresh enough. items are the headlines to brief on;
+// nil fetches them.
+func loadOrGenerateBrief(ctx context.Context, cfg *config.Config, items []feeds.NewsItem) *intel.Brief {
+	if cfg.BriefCacheMins > 0 {
+		cached, err := intel.LoadCachedBrief(time.Duration(cfg.BriefCacheMins) * time.Minute)
+		if err == nil && cached != nil {
+			return cached.WithSections(ui.BriefOptions(cfg))
+		}
+	}
+	if items == nil {
+		var err error
+		if items, _, err = feeds.FetchGlobalNews(ctx, ui.FeedOptions(cfg)); err != nil {
+			return nil
+		}
+	}
+	b, err := intel.GenerateBrief(ctx, ui.LLMConfig(cfg), items, ui.BriefOptions(cfg))
+	if err != nil {
+		return nil
+	}
+	intel.SaveCachedBrief(b)
+	intel.AppendRiskHistory(b)
+	return b
+}
+
+// runDigest implements `watchtower digest [-o file] [--format markdown|html]
+// [--headlines n]`: a one-shot digest of the top headlines, the brief and
+// the market movers, meant to be run from cron and piped into a mail tool.
+func runDigest(args []string) {
+	fs := flag.NewFlagSet("digest", flag.ExitOnError)
+	out := fs.String("o", "", "write the digest to this file instead of stdout")
+	format := fs.String("format", "markdown", "markdown or html")
+	headlines := fs.Int("headlines", 10, "how many critical and high headlines to include")
+	fs.Parse(args)
+
+	if *format != "markdown" && *format != "html" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use markdown or html)\n", *format)
+		os.Exit(1)
+	}
+
+	cfg := loadConfigOrExit()
+	ctx := context.Background()
+
+	items, _, err := feeds.FetchGlobalNews(ctx, ui.FeedOptions(cfg))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching news: %v\n", err)
+		os.Exit(1)
+	}
+	d := report.Digest{
+		Snapshot:  fetchDigestSnapshot(ctx, cfg, items),
+		Headlines: report.TopHeadlines(items, *headlines),
+	}
+
+	text := report.DigestMarkdown(d)
+	if *format == "html" {
+		if text, err = report.DigestHTML(d); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *out == "" {
+		fmt.Print(text)
+		return
+	}
+	if err := os.WriteFile(*out, []byte(text), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing digest: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// fetchDigestSnapshot is fetchSnapshot without the weather, briefing on
+// the already fetched items so the news is only downloaded once.
+func fetchDigestSnapshot(ctx context.Context, cfg *config.Config, items []feeds.NewsItem) report.Snapshot {
+	snap := report.Snapshot{TakenAt: time.Now(), City: cfg.Location.City}
+	var wg sync.WaitGroup
+	run := func(f func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f()
+		}()
+	}
+
+	run(func() { snap.Crypto, _ = markets.FetchCryptoPrices(ctx, ui.CryptoOptions(cfg), cfg.CryptoPairs) })
+	run(func() { snap.Indices, _ = markets.FetchStockIndices(ctx) })
+	run(func() { snap.Commodities, _ = markets.FetchCommodities(ctx, ui.CommodityOptions(cfg)) })
+	run(func() { snap.Brief = loadOrGenerateBrief(ctx, cfg, items) })
+
+	wg.Wait()
+	return snap
+}
+
+// runRiskHistory implements `watchtower risk-history [--country name] [--csv]`,
+// printing the country risk scores recorded from past briefs.
+func runRiskHistory(args []string) {
+	fs := flag.NewFlagSet("risk-history", flag.ExitOnError)
+	country := fs.String("country", "", "only show this country")
+	asCSV := fs.Bool("csv", false, "print raw CSV for charting tools")
+	fs.Parse(args)
+
+	loadDataDir()
+	records, err := intel.LoadRiskHistory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading risk history: %v\n", err)
+		os.Exit(1)
+	}
+	if *country != "" {
+		want := intel.NormalizeCountry(*country)
+		var filtered []intel.RiskRecord
+		for _, r := range records {
+			if strings.EqualFold(r.Country, want) {
+				filtered = append(filtered, r)
+			}
+		}
+		records = filtered
+	}
+	if len(records) == 0 {
+		fmt.Println("No risk history recorded yet. Generate a brief to start collecting.")
+		return
+	}
+
+	if *asCSV {
+		w := csv.NewWriter(os.Stdout)
+		w.Write([]string{"timestamp", "country", "score"})
+		for _, r := range records {
+			w.Write([]string{r.Time.Format(time.RFC3339), r.Country, strconv.Itoa(r.Score)})
+		}
+		w.Flush()
+		return
+	}
+	for _, r := range records {
+		fmt.Printf("%s  %-24s %3d\n", r.Time.Local().Format("2006-01-02 15:04"), r.Country, r.Score)
+	}
+}
+
+// runInit implements `watchtower init [--force]`: it writes a commented
+// sample config for users who would rather edit a file than run setup.
+func runInit(args []string) {
+	fs := flag.NewFlagSet("init", flag.ExitOnError)
+	force := fs.Bool("force", false, "overwrite an existing config")
+	fs.Parse(args)
+
+	path, err := config.WriteSample(*force)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote sample config to %s\n", path)
+	fmt.Println("Edit it (at least location and llm_api_key), then run watchtower.")
+}
+
+// runReset implements `watchtower reset [--purge] [--yes]`: it deletes the
+// cached briefs and recorded histories for a clean slate, and with --purge
+// the config too, so the next start runs setup again.
+func runReset(args []string) {
+	fs := flag.NewFlagSet("reset", flag.ExitOnError)
+	purge := fs.Bool("purge", false, "also delete config.yaml")
+	yes := fs.Bool("yes", false, "don't ask for confirmation")
+	fs.Parse(args)
+
+	loadDataDir()
+
+	what := "cached briefs, risk history and price history"
+	if *purge {
+		what += ", and your config (including API keys)"
+	}
+	if !*yes {
+		fmt.Printf("This deletes %s. Continue? [y/N] ", what)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Println("Nothing removed.")
+			return
+		}
+	}
+
+	clears := []func() (string, error){
+		intel.ClearBriefCache,
+		intel.ClearLocalBriefCache,
+		intel.ClearSafetyBriefCache,
+		intel.ClearRiskHistory,
+		markets.ClearPriceHistory,
+	}
+	if *purge {
+		clears = append(clears, config.Remove)
+	}
+	removed, failed := 0, false
+	for _, fn := range clears {
+		path, err := fn()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			failed = true
+			continue
+		}
+		if path != "" {
+			fmt.Println("Removed " + path)
+			removed++
+		}
+	}
+	if removed == 0 && !failed {
+		fmt.Println("Nothing to remove; already clean.")
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -1,29 +1,200 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+	"watchtower/fixtures"
+	"watchtower/paths"
+	"watchtower/weather"
 )
 
 var httpClient = &http.Client{Timeout: 10 * time.Second}
 
 type Config struct {
-	LLMProvider    string   `mapstructure:"llm_provider"`
-	LLMAPIKey      string   `mapstructure:"llm_api_key"`
-	LLMModel       string   `mapstructure:"llm_model"`
-	Location       Location `mapstructure:"location"`
-	TempUnit       string   `mapstructure:"temp_unit"`
-	RefreshSec     int      `mapstructure:"refresh_seconds"`
-	CryptoPairs    []string `mapstructure:"crypto_pairs"`
-	BriefCacheMins int      `mapstructure:"brief_cache_minutes"`
+	LLMProvider        string            `mapstructure:"llm_provider"`
+	LLMAPIKey          string            `mapstructure:"llm_api_key"`
+	LLMAPIKeyCmd       string            `mapstructure:"llm_api_key_command"` // e.g. "pass show watchtower/groq"; stdout overrides llm_api_key
+	LLMModel           string            `mapstructure:"llm_model"`
+	LLMFallbacks       []LLMFallback     `mapstructure:"llm_fallbacks"` // providers tried in order when llm_provider fails
+	LLMHeaders         map[string]string `mapstructure:"llm_headers"`   // extra headers on every LLM request, e.g. a gateway tenant id
+	LLMOverrides       ProviderOverrides `mapstructure:"llm_overrides"` // per-provider endpoint and auth overrides, keyed by provider
+	Azure              Azure             `mapstructure:"azure"`         // Azure OpenAI deployment, for llm_provider: azure
+	Location           Location          `mapstructure:"location"`
+	TempUnit           string            `mapstructure:"temp_unit"`
+	WeatherProvider    string            `mapstructure:"weather_provider"` // primary weather source: open-meteo or wttr (the other is the fallback)
+	WindUnit           string            `mapstructure:"wind_unit"`        // kmh, mph, ms or knots
+	VisibilityUnit     string            `mapstructure:"visibility_unit"`  // km or mi
+	Language           string            `mapstructure:"language"`         // UI language for weather and threat labels: en, es, de, pt
+	RefreshSec         int               `mapstructure:"refresh_seconds"`
+	NewsRefreshSec     int               `mapstructure:"news_refresh_seconds"`     // global/local news and alerts; 0 = refresh_seconds
+	CryptoRefreshSec   int               `mapstructure:"crypto_refresh_seconds"`   // 0 = refresh_seconds
+	MarketsRefreshSec  int               `mapstructure:"markets_refresh_seconds"`  // indices, commodities, prediction markets; 0 = refresh_seconds
+	WeatherRefreshSec  int               `mapstructure:"weather_refresh_seconds"`  // 0 = refresh_seconds
+	FocusRefreshSec    int               `mapstructure:"refresh_on_focus_seconds"` // refresh on regaining focus if data is older than this; 0 = off
+	CryptoPairs        []string          `mapstructure:"crypto_pairs"`
+	BriefCacheMins     int               `mapstructure:"brief_cache_minutes"`
+	BriefHeadlines     int               `mapstructure:"brief_headline_count"`       // headlines sent to the LLM; capped at 100
+	BriefStalePct      int               `mapstructure:"brief_stale_percent"`        // flag a cached brief as stale in its last N% of brief_cache_minutes; <0 disables
+	BriefWeather       bool              `mapstructure:"brief_include_weather"`      // add a line of local weather to the global brief prompt
+	BriefTrigger       string            `mapstructure:"brief_trigger"`              // when the brief generates unasked: news, overview or manual
+	BriefSoftMins      int               `mapstructure:"brief_soft_refresh_minutes"` // regenerate a shown brief older than this in the background; 0 = off
+	BriefLanguage      string            `mapstructure:"brief_language"`             // language the brief is written in, e.g. French; empty = English
+	BrowserCommand     string            `mapstructure:"browser_command"`            // e.g. "firefox --new-tab %u"; %u is the URL
+	CryptoProvider     string            `mapstructure:"crypto_provider"`            // primary crypto source: coingecko or binance
+	CoinGeckoKey       string            `mapstructure:"coingecko_api_key"`          // demo or pro key; raises rate limits
+	CoinGeckoPro       bool              `mapstructure:"coingecko_pro"`              // key is a paid plan key: use pro-api.coingecko.com
+	PriceHistorySize   int               `mapstructure:"price_history_size"`         // refreshes kept per asset for the trend column; <0 disables
+	PriceHistorySave   bool              `mapstructure:"price_history_persist"`      // keep the trend history across restarts
+	MarketStaleMins    int               `mapstructure:"market_stale_minutes"`       // mark prices older than this during trading hours as stale; <0 disables
+	DataDir            string            `mapstructure:"data_dir"`                   // cached briefs and risk/price history; "" = ~/.cache/watchtower
+	CacheMaxMB         int               `mapstructure:"cache_max_mb"`               // size data_dir is pruned to at startup; <0 disables
+	CacheMaxAgeDays    int               `mapstructure:"cache_max_age_days"`         // cached briefs untouched this long are deleted at startup; <0 disables
+	QuietHours         QuietHours        `mapstructure:"quiet_hours"`
+	WeatherAdvice      bool              `mapstructure:"weather_advice"`   // one-line clothing hint in the weather panel
+	WeatherAstro       bool              `mapstructure:"weather_astro"`    // sunrise, sunset and moon phase line in the weather panel
+	NewsRiskPanel      bool              `mapstructure:"news_risk_panel"`  // country risk panel above the global news list; toggled with R
+	PinnedTopics       []string          `mapstructure:"pinned_topics"`    // title keywords always sorted to the top
+	MutedSources       []string          `mapstructure:"muted_sources"`    // feed names (e.g. "Politico") never fetched
+	MutedKeywords      []string          `mapstructure:"muted_keywords"`   // title keywords whose items are hidden
+	LocalFeeds         []FeedSource      `mapstructure:"local_feeds"`      // extra local news feeds
+	SourceTags         bool              `mapstructure:"source_tags"`      // short colored source tags (REU, BBC) instead of full names
+	SourceCounts       bool              `mapstructure:"source_counts"`    // "(5 sources)" after stories several feeds ran
+	CrossDedup         string            `mapstructure:"cross_dedup"`      // local stories also in global news: off, dim or hide
+	Relevance          Relevance         `mapstructure:"relevance"`        // global news about your location: off, boost or only
+	LocalBriefMode     string            `mapstructure:"local_brief_mode"` // Local tab brief: summary or safety; toggled with S
+	LocalFeedsMode     string            `mapstructure:"local_feeds_mode"` // "augment" Google News with local_feeds, or "replace" it
+	GoogleNews         GoogleNews        `mapstructure:"google_news"`
+	ClassifySummaries  bool              `mapstructure:"classify_summaries"`      // also rate threat level from each item's description
+	EnterAction        string            `mapstructure:"enter_action"`            // what enter does on an article: browser, reader or copy
+	DescriptionLines   int               `mapstructure:"description_lines"`       // lines of summary shown when an article is expanded with e
+	MaxContentWidth    int               `mapstructure:"max_content_width"`       // cap layout width on ultrawide terminals; 0 = unlimited
+	AccentColor        string            `mapstructure:"accent_color"`            // hex color for titles, sources, active tab and spinner; "" = #58a6ff
+	ConfirmQuit        bool              `mapstructure:"confirm_quit"`            // ask "Quit? (y/n)" on q/ctrl+c
+	GlanceView         bool              `mapstructure:"glance_view"`             // start in the few-line glance view instead of the dashboard
+	CompactHeaderWidth int               `mapstructure:"compact_header_width"`    // below this many columns the header shrinks to "WT" + status; <0 never
+	BreakingMins       int               `mapstructure:"breaking_minutes"`        // critical items younger than this flash as BREAKING; <0 disables
+	BaseCurrency       string            `mapstructure:"base_currency"`           // ISO code commodity prices are converted to
+	CommodityUnits     string            `mapstructure:"commodity_units"`         // "us" (bbl, oz, lb) or "metric" (bbl, g, kg)
+	RefreshTimeoutSec  int               `mapstructure:"refresh_timeout_seconds"` // overall deadline for one refresh of all panels
+	ResumeGapSec       int               `mapstructure:"resume_gap_seconds"`      // a timer this late means the machine slept: refresh everything; <0 disables
+	BriefSections      BriefSections     `mapstructure:"brief_sections"`
+	ThreatLines        ThreatLines       `mapstructure:"threat_lines"`
+	WebhookFeedURL     string            `mapstructure:"webhook_feed_url"`        // JSON alerts endpoint polled each refresh
+	WebhookHeaders     map[string]string `mapstructure:"webhook_feed_headers"`    // e.g. Authorization for the alerts endpoint
+	ChangeThreshold    float64           `mapstructure:"market_change_threshold"` // moves smaller than this many percent render muted
+	ChangeStrong       float64           `mapstructure:"market_change_strong"`    // moves at or beyond this many percent render bold; 0 = off
+	SpinnerStyle       string            `mapstructure:"spinner_style"`           // bubbles spinner preset name; unknown names use dot
+	ASCIIIcons         bool              `mapstructure:"ascii_icons"`             // fixed-width ASCII instead of emoji for weather and news markers
+}
+
+// BriefSections toggles the optional parts of the intel brief. Disabled
+// sections are neither requested from the LLM nor shown.
+type BriefSections struct {
+	Threats      bool `mapstructure:"threats"`
+	CountryRisks bool `mapstructure:"country_risks"`
+}
+
+// ThreatLines caps how many lines each key threat takes in the brief panel,
+// separately for the dashboard and for focus mode; 0 wraps the whole threat.
+type ThreatLines struct {
+	Overview int `mapstructure:"overview"`
+	Focus    int `mapstructure:"focus"`
+}
+
+// ProviderOverrides maps provider names to their llm_overrides entry
+type ProviderOverrides map[string]LLMOverride
+
+// LLMOverride points a provider at a gateway or proxy instead of its public
+// API. Empty fields keep the provider's default; AuthPrefix is only used
+// together with AuthHeader, so "" there means no prefix.
+type LLMOverride struct {
+	Endpoint   string `mapstructure:"endpoint"`
+	AuthHeader string `mapstructure:"auth_header"`
+	AuthPrefix string `mapstructure:"auth_prefix"`
+}
+
+// LLMFallback is a backup provider for the brief
+type LLMFallback struct {
+	Provider string `mapstructure:"provider"`
+	APIKey   string `mapstructure:"api_key"`
+	Model    string `mapstructure:"model"`
+}
+
+// FeedSource is a named RSS/Atom feed
+type FeedSource struct {
+	Name string `mapstructure:"name"`
+	URL  string `mapstructure:"url"`
+}
+
+// Azure locates the Azure OpenAI deployment used by the azure provider.
+// Endpoint is the resource URL, e.g. https://myres.openai.azure.com.
+type Azure struct {
+	Endpoint   string `mapstructure:"endpoint"`
+	Deployment string `mapstructure:"deployment"`
+	APIVersion string `mapstructure:"api_version"`
+}
+
+// Relevance ranks Global News items whose title mentions the user's city,
+// country or one of Keywords: "boost" lists them first, "only" hides the
+// rest. "off" (the default) leaves the global news global.
+type Relevance struct {
+	Mode     string   `mapstructure:"mode"`
+	Keywords []string `mapstructure:"keywords"`
+}
+
+// GoogleNews sets the language and region of the Google News local feeds,
+// e.g. language "ja" with region "JP" for Japanese, or "en" for English
+// coverage of Japan. Empty values use en and the location's country.
+type GoogleNews struct {
+	Language string `mapstructure:"language"`
+	Region   string `mapstructure:"region"`
+}
+
+// QuietHours is a daily local-time window ("HH:MM") during which the
+// auto-refresh is paused. The window may wrap past midnight.
+type QuietHours struct {
+	Start string `mapstructure:"start"`
+	End   string `mapstructure:"end"`
+}
+
+// Active reports whether t falls within the quiet window. An unset or
+// malformed window is never active.
+func (q QuietHours) Active(t time.Time) bool {
+	start, ok1 := parseClock(q.Start)
+	end, ok2 := parseClock(q.End)
+	if !ok1 || !ok2 || start == end {
+		return false
+	}
+	now := t.Hour()*60 + t.Minute()
+	if start < end {
+		return now >= start && now < end
+	}
+	// Wraps midnight, e.g. 23:00–07:00
+	return now >= start || now < end
+}
+
+// parseClock parses "HH:MM" into minutes since midnight
+func parseClock(s string) (int, bool) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
 }
 
 type Location struct {
@@ -53,32 +224,352 @@ func Load() (*Config, error) {
 	// Allow env override for API key
 	viper.BindEnv("llm_api_key", "LLM_API_KEY")
 
+	// Defaults for booleans that are on unless explicitly disabled
+	viper.SetDefault("weather_advice", true)
+	viper.SetDefault("news_risk_panel", true)
+	viper.SetDefault("source_counts", true)
+	viper.SetDefault("brief_sections.threats", true)
+	viper.SetDefault("brief_sections.country_risks", true)
+
+	if data, err := os.ReadFile(cfgFile); err == nil && len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("%w: %s is empty", ErrInvalid, cfgFile)
+	}
 	if err := viper.ReadInConfig(); err != nil {
+		if errors.As(err, new(viper.ConfigParseError)) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
 		return nil, fmt.Errorf("reading config: %w", err)
 	}
 
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
-		return nil, fmt.Errorf("parsing config: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	if cfg.LLMAPIKeyCmd != "" {
+		key, err := secretFromCommand(cfg.LLMAPIKeyCmd)
+		if err != nil {
+			return nil, fmt.Errorf("llm_api_key_command: %w", err)
+		}
+		cfg.LLMAPIKey = key
+	}
+	// Fixture briefs need no key, but the dashboard only offers AI
+	// features when one is set
+	if fixtures.Dir() != "" && cfg.LLMAPIKey == "" {
+		cfg.LLMAPIKey = "fixtures"
 	}
 
 	// Defaults
 	if cfg.RefreshSec == 0 {
 		cfg.RefreshSec = 120
 	}
+	for _, secs := range []*int{&cfg.NewsRefreshSec, &cfg.CryptoRefreshSec, &cfg.MarketsRefreshSec, &cfg.WeatherRefreshSec} {
+		if *secs <= 0 {
+			*secs = cfg.RefreshSec
+		}
+	}
 	if cfg.BriefCacheMins == 0 {
 		cfg.BriefCacheMins = 60
 	}
+	if cfg.BriefHeadlines <= 0 {
+		cfg.BriefHeadlines = 40
+	}
+	if cfg.BriefStalePct == 0 {
+		cfg.BriefStalePct = 10
+	}
+	if cfg.PriceHistorySize == 0 {
+		cfg.PriceHistorySize = 12
+	}
+	if cfg.MarketStaleMins == 0 {
+		cfg.MarketStaleMins = 15
+	}
+	if cfg.CacheMaxMB == 0 {
+		cfg.CacheMaxMB = 50
+	}
+	if cfg.CacheMaxAgeDays == 0 {
+		cfg.CacheMaxAgeDays = 180
+	}
 	if len(cfg.CryptoPairs) == 0 {
 		cfg.CryptoPairs = []string{"bitcoin", "ethereum", "dogecoin", "usd-coin"}
 	}
 	if cfg.TempUnit == "" {
 		cfg.TempUnit = "celsius"
 	}
+	if cfg.WeatherProvider == "" {
+		cfg.WeatherProvider = "open-meteo"
+	}
+	if cfg.WindUnit == "" {
+		cfg.WindUnit = "kmh"
+	}
+	if cfg.VisibilityUnit == "" {
+		cfg.VisibilityUnit = "km"
+	}
+	if cfg.CryptoProvider == "" {
+		cfg.CryptoProvider = "coingecko"
+	}
+	if cfg.EnterAction == "" {
+		cfg.EnterAction = "browser"
+	}
+	if cfg.CrossDedup == "" {
+		cfg.CrossDedup = "off"
+	}
+	if cfg.Relevance.Mode == "" {
+		cfg.Relevance.Mode = "off"
+	}
+	if cfg.LocalBriefMode == "" {
+		cfg.LocalBriefMode = "summary"
+	}
+	if cfg.LocalFeedsMode == "" {
+		cfg.LocalFeedsMode = "augment"
+	}
+	for i, f := range cfg.LocalFeeds {
+		if f.Name == "" {
+			cfg.LocalFeeds[i].Name = f.URL
+		}
+	}
+	if cfg.BriefTrigger == "" {
+		cfg.BriefTrigger = "news"
+	}
+	if cfg.BreakingMins == 0 {
+		cfg.BreakingMins = 15
+	}
+	if cfg.DescriptionLines <= 0 {
+		cfg.DescriptionLines = 4
+	}
+	cfg.BaseCurrency = strings.ToUpper(strings.TrimSpace(cfg.BaseCurrency))
+	if cfg.BaseCurrency == "" {
+		cfg.BaseCurrency = "USD"
+	}
+	if cfg.CommodityUnits == "" {
+		cfg.CommodityUnits = "us"
+	}
+	if cfg.CompactHeaderWidth == 0 {
+		cfg.CompactHeaderWidth = 80
+	}
+	if cfg.RefreshTimeoutSec <= 0 {
+		cfg.RefreshTimeoutSec = 45
+	}
+	if cfg.ResumeGapSec == 0 {
+		cfg.ResumeGapSec = 60
+	}
+	cfg.DataDir = strings.TrimSpace(cfg.DataDir)
+	if rest, ok := strings.CutPrefix(cfg.DataDir, "~/"); ok {
+		cfg.DataDir = filepath.Join(home, rest)
+	}
+	// Commands that touch the caches load the config first (the dashboard,
+	// brief, snapshot and digest need it anyway; reset and risk-history
+	// via loadDataDir), so this is where the data directory is applied
+	if filepath.IsAbs(cfg.DataDir) {
+		paths.SetDataDir(cfg.DataDir)
+	}
 
 	return &cfg, nil
 }
 
+// secretFromCommand runs a shell command (e.g. a password manager CLI) and
+// returns its trimmed stdout. An empty result is an error so a locked vault
+// doesn't silently look like "no key".
+func secretFromCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdin = os.Stdin // let the password manager prompt if it needs to
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%q failed: %w: %s", command, err, msg)
+		}
+		return "", fmt.Errorf("%q failed: %w", command, err)
+	}
+	secret := strings.TrimSpace(string(out))
+	if secret == "" {
+		return "", fmt.Errorf("%q printed nothing", command)
+	}
+	return secret, nil
+}
+
+// MaskSecret hides all but the last 4 characters of a secret for display,
+// e.g. "••••••••wxyz". Short secrets are hidden entirely.
+func MaskSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	if len(s) <= 8 {
+		return strings.Repeat("•", len(s))
+	}
+	return strings.Repeat("•", 8) + s[len(s)-4:]
+}
+
+// restrictPerms makes the config file owner-only since it may hold an API key
+func restrictPerms(path string) error {
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("restricting config permissions: %w", err)
+	}
+	return nil
+}
+
+// Path returns the location of config.yaml
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "watchtower", "config.yaml"), nil
+}
+
+// Remove deletes config.yaml, returning its path, or "" if there was none
+func Remove() (string, error) {
+	path, err := Path()
+	if err != nil {
+		return "", err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Warnings reports non-fatal problems with the loaded config
+func (c *Config) Warnings() []string {
+	var warns []string
+	if c.BriefHeadlines > 100 {
+		warns = append(warns, fmt.Sprintf("brief_headline_count %d is above the maximum; using 100", c.BriefHeadlines))
+	}
+	if c.BrowserCommand != "" {
+		fields := strings.Fields(c.BrowserCommand)
+		if len(fields) == 0 {
+			warns = append(warns, "browser_command is blank; using the system default browser")
+		} else if _, err := exec.LookPath(fields[0]); err != nil {
+			warns = append(warns, fmt.Sprintf("browser_command %q not found on PATH", fields[0]))
+		}
+	}
+	if q := c.QuietHours; q.Start != "" || q.End != "" {
+		_, ok1 := parseClock(q.Start)
+		_, ok2 := parseClock(q.End)
+		if !ok1 || !ok2 {
+			warns = append(warns, "quiet_hours start/end must both be HH:MM; quiet hours disabled")
+		}
+	}
+	if !weather.ProviderValid(c.WeatherProvider) {
+		warns = append(warns, fmt.Sprintf("unknown weather_provider %q; using open-meteo", c.WeatherProvider))
+	}
+	switch c.WindUnit {
+	case "kmh", "mph", "ms", "knots":
+	default:
+		warns = append(warns, fmt.Sprintf("unknown wind_unit %q; using kmh", c.WindUnit))
+	}
+	if c.VisibilityUnit != "km" && c.VisibilityUnit != "mi" {
+		warns = append(warns, fmt.Sprintf("unknown visibility_unit %q; using km", c.VisibilityUnit))
+	}
+	if c.AccentColor != "" && !ValidHexColor(c.AccentColor) {
+		warns = append(warns, fmt.Sprintf("accent_color %q is not a hex color like #58a6ff; using the default", c.AccentColor))
+	}
+	if c.CoinGeckoPro && c.CoinGeckoKey == "" {
+		warns = append(warns, "coingecko_pro needs coingecko_api_key; using the free public API")
+	}
+	if c.CryptoProvider != "coingecko" && c.CryptoProvider != "binance" {
+		warns = append(warns, fmt.Sprintf("unknown crypto_provider %q; falling back to coingecko", c.CryptoProvider))
+	}
+	switch c.CrossDedup {
+	case "off", "dim", "hide":
+	default:
+		warns = append(warns, fmt.Sprintf("unknown cross_dedup %q; local news is not deduplicated", c.CrossDedup))
+	}
+	if c.DataDir != "" && !filepath.IsAbs(c.DataDir) {
+		warns = append(warns, fmt.Sprintf("data_dir %q is not an absolute path; using ~/.cache/watchtower", c.DataDir))
+	}
+	switch c.Relevance.Mode {
+	case "off", "boost", "only":
+	default:
+		warns = append(warns, fmt.Sprintf("unknown relevance mode %q; global news is not ranked by location", c.Relevance.Mode))
+	}
+	if c.LocalBriefMode != "summary" && c.LocalBriefMode != "safety" {
+		warns = append(warns, fmt.Sprintf("unknown local_brief_mode %q; showing the summary", c.LocalBriefMode))
+	}
+	switch c.LocalFeedsMode {
+	case "augment":
+	case "replace":
+		if len(c.LocalFeeds) == 0 {
+			warns = append(warns, "local_feeds_mode is replace but local_feeds is empty; using Google News")
+		}
+	default:
+		warns = append(warns, fmt.Sprintf("unknown local_feeds_mode %q; adding local_feeds to Google News", c.LocalFeedsMode))
+	}
+	switch c.BriefTrigger {
+	case "news", "overview", "manual":
+	default:
+		warns = append(warns, fmt.Sprintf("unknown brief_trigger %q; the brief will generate when news loads", c.BriefTrigger))
+	}
+	switch c.EnterAction {
+	case "browser", "reader", "copy":
+	default:
+		warns = append(warns, fmt.Sprintf("unknown enter_action %q; enter will open the browser", c.EnterAction))
+	}
+	if len(c.BaseCurrency) != 3 {
+		warns = append(warns, fmt.Sprintf("base_currency %q is not a 3-letter ISO code; prices may stay in USD", c.BaseCurrency))
+	}
+	if c.LLMAPIKey != "" && c.LLMAPIKeyCmd == "" && fixtures.Dir() == "" && runtime.GOOS != "windows" {
+		if path, err := Path(); err == nil {
+			if fi, err := os.Stat(path); err == nil && fi.Mode().Perm()&0077 != 0 {
+				warns = append(warns, fmt.Sprintf("%s holds an API key but is readable by others; run chmod 600 on it", path))
+			}
+		}
+	}
+	if c.LLMProvider == "openrouter" && c.LLMModel != "" && !validOpenRouterModel(c.LLMModel) {
+		warns = append(warns, fmt.Sprintf("llm_model %q is not an OpenRouter model id; use vendor/model, e.g. openai/gpt-4o-mini", c.LLMModel))
+	}
+	usesAzure := c.LLMProvider == "azure"
+	for _, f := range c.LLMFallbacks {
+		usesAzure = usesAzure || f.Provider == "azure"
+	}
+	if usesAzure && (c.Azure.Endpoint == "" || c.Azure.Deployment == "") {
+		warns = append(warns, "the azure provider needs azure.endpoint and azure.deployment")
+	}
+	if c.CommodityUnits != "us" && c.CommodityUnits != "metric" {
+		warns = append(warns, fmt.Sprintf("unknown commodity_units %q; using us units", c.CommodityUnits))
+	}
+	return warns
+}
+
+// validOpenRouterModel reports whether model looks like an OpenRouter id:
+// vendor/model, optionally with a variant suffix such as ":free".
+func validOpenRouterModel(model string) bool {
+	vendor, name, ok := strings.Cut(model, "/")
+	return ok && vendor != "" && name != "" && !strings.ContainsAny(model, " \t") && !strings.Contains(name, "/")
+}
+
+// ErrInvalid marks a config file that exists but can't be used: empty,
+// malformed YAML, or values of the wrong type.
+var ErrInvalid = errors.New("config file is empty or malformed")
+
+// BackupBroken moves an unusable config.yaml aside as
+// config.yaml.broken-<timestamp> so setup can write a fresh one, and
+// returns the backup's path.
+func BackupBroken() (string, error) {
+	cfgFile, err := Path()
+	if err != nil {
+		return "", err
+	}
+	backup := cfgFile + ".broken-" + time.Now().Format("20060102-150405")
+	if err := os.Rename(cfgFile, backup); err != nil {
+		return "", fmt.Errorf("backing up config: %w", err)
+	}
+	return backup, nil
+}
+
 func ConfigExists() bool {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -122,44 +613,168 @@ func Save(cfg *Config) error {
 		return fmt.Errorf("writing config: %w", err)
 	}
 
-	return nil
+	return restrictPerms(cfgFile)
 }
 
-func Geocode(ctx context.Context, city, countryCode string) (lat, lon float64, err error) {
-	url := fmt.Sprintf(
-		"https://geocoding-api.open-meteo.com/v1/search?name=%s&country=%s&count=1&language=en&format=json",
-		url.QueryEscape(city), countryCode,
+// hexColor matches #rgb and #rrggbb
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ValidHexColor reports whether s is a #rgb or #rrggbb color
+func ValidHexColor(s string) bool {
+	return hexColor.MatchString(s)
+}
+
+// HasCoordinates reports whether the location carries real coordinates.
+// A hand-edited config often leaves lat/lon at 0,0, which Open-Meteo
+// happily answers for (the Gulf of Guinea).
+func (l Location) HasCoordinates() bool {
+	return l.Latitude != 0 || l.Longitude != 0
+}
+
+// ResolveLocation geocodes the city when the location has no coordinates.
+// Locations that already have coordinates are returned unchanged.
+func ResolveLocation(ctx context.Context, loc Location) (Location, error) {
+	if loc.HasCoordinates() {
+		return loc, nil
+	}
+	if loc.City == "" {
+		return loc, fmt.Errorf("no location configured: set location.city or latitude/longitude")
+	}
+	lat, lon, err := Geocode(ctx, loc.City, loc.Country)
+	if err != nil {
+		return loc, fmt.Errorf("could not resolve coordinates for %q: %w", loc.City, err)
+	}
+	loc.Latitude = lat
+	loc.Longitude = lon
+	return loc, nil
+}
+
+// SaveLocation persists the location's fields in place; comments and the
+// other settings in config.yaml are kept. A no-op in fixture mode.
+func SaveLocation(loc Location) error {
+	return saveSettings(
+		setting{"location.city", loc.City},
+		setting{"location.country", loc.Country},
+		setting{"location.latitude", loc.Latitude},
+		setting{"location.longitude", loc.Longitude},
 	)
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+// SaveSetting persists one key (dotted for nested ones, e.g.
+// "brief_sections.threats") in place; comments and the other settings in
+// config.yaml are kept. A no-op in fixture mode.
+func SaveSetting(key string, value interface{}) error {
+	return saveSettings(setting{key, value})
+}
+
+// GeoCandidate is one place returned by the geocoding search
+type GeoCandidate struct {
+	Name        string
+	Region      string // state/province, may be empty
+	Country     string
+	CountryCode string
+	Latitude    float64
+	Longitude   float64
+}
+
+// Label renders the candidate as "Name, Region, Country" for pick lists
+func (c GeoCandidate) Label() string {
+	parts := []string{c.Name}
+	if c.Region != "" && c.Region != c.Name {
+		parts = append(parts, c.Region)
+	}
+	if c.Country != "" {
+		parts = append(parts, c.Country)
+	} else if c.CountryCode != "" {
+		parts = append(parts, c.CountryCode)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Geocode resolves a city to coordinates, taking the best match
+func Geocode(ctx context.Context, city, countryCode string) (lat, lon float64, err error) {
+	candidates, err := GeocodeCandidates(ctx, city, countryCode)
 	if err != nil {
-		return 0, 0, fmt.Errorf("creating geocoding request: %w", err)
+		return 0, 0, err
+	}
+	return candidates[0].Latitude, candidates[0].Longitude, nil
+}
+
+// GeocodeCandidates returns up to a few places matching city. When the
+// country filter yields nothing (small towns, a mistyped country code) the
+// search is retried by name alone.
+func GeocodeCandidates(ctx context.Context, city, countryCode string) ([]GeoCandidate, error) {
+	candidates, err := geocodeSearch(ctx, city, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 && countryCode != "" {
+		if candidates, err = geocodeSearch(ctx, city, ""); err != nil {
+			return nil, err
+		}
+	}
+	if len(candidates) == 0 {
+		if countryCode != "" {
+			return nil, fmt.Errorf("no place named %q found (also searched outside %s)", city, countryCode)
+		}
+		return nil, fmt.Errorf("no place named %q found", city)
+	}
+	return candidates, nil
+}
+
+const geocodeMaxCandidates = 5
+
+func geocodeSearch(ctx context.Context, city, countryCode string) ([]GeoCandidate, error) {
+	q := url.Values{}
+	q.Set("name", city)
+	q.Set("count", strconv.Itoa(geocodeMaxCandidates))
+	q.Set("language", "en")
+	q.Set("format", "json")
+	if countryCode != "" {
+		q.Set("country", countryCode)
+	}
+	endpoint := "https://geocoding-api.open-meteo.com/v1/search?" + q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating geocoding request: %w", err)
 	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return 0, 0, fmt.Errorf("geocoding request failed: %w", err)
+		return nil, fmt.Errorf("geocoding request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return 0, 0, fmt.Errorf("geocoding API HTTP %d", resp.StatusCode)
+		return nil, fmt.Errorf("geocoding API HTTP %d", resp.StatusCode)
 	}
 
 	var result struct {
 		Results []struct {
-			Latitude  float64 `json:"latitude"`
-			Longitude float64 `json:"longitude"`
+			Name        string  `json:"name"`
+			Admin1      string  `json:"admin1"`
+			Country     string  `json:"country"`
+			CountryCode string  `json:"country_code"`
+			Latitude    float64 `json:"latitude"`
+			Longitude   float64 `json:"longitude"`
 		} `json:"results"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, 0, fmt.Errorf("decoding geocoding response: %w", err)
+		return nil, fmt.Errorf("decoding geocoding response: %w", err)
 	}
 
-	if len(result.Results) == 0 {
-		return 0, 0, fmt.Errorf("city not found: %s, %s", city, countryCode)
+	candidates := make([]GeoCandidate, 0, len(result.Results))
+	for _, r := range result.Results {
+		candidates = append(candidates, GeoCandidate{
+			Name:        r.Name,
+			Region:      r.Admin1,
+			Country:     r.Country,
+			CountryCode: r.CountryCode,
+			Latitude:    r.Latitude,
+			Longitude:   r.Longitude,
+		})
 	}
-
-	return result.Results[0].Latitude, result.Results[0].Longitude, nil
+	return candidates, nil
 }
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"watchtower/fixtures"
+	"watchtower/paths"
+)
+
+func TestLoadDataDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(fixtures.EnvVar, "")
+	defer paths.SetDataDir("")
+
+	tests := []struct {
+		dataDir string
+		want    string
+		warns   bool
+	}{
+		{"", filepath.Join(home, ".cache", "watchtower"), false},
+		{"~/Sync/watchtower", filepath.Join(home, "Sync", "watchtower"), false},
+		{"/var/lib/watchtower", "/var/lib/watchtower", false},
+		{"relative/dir", filepath.Join(home, ".cache", "watchtower"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dataDir, func(t *testing.T) {
+			paths.SetDataDir("")
+			data, err := setYAML([]byte(SampleConfig), setting{"data_dir", tt.dataDir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			path, _ := Path()
+			os.MkdirAll(filepath.Dir(path), 0755)
+			if err := os.WriteFile(path, data, 0600); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := paths.CacheDir(home); got != tt.want {
+				t.Errorf("CacheDir = %q, want %q", got, tt.want)
+			}
+			warned := false
+			for _, w := range cfg.Warnings() {
+				warned = warned || strings.HasPrefix(w, "data_dir")
+			}
+			if warned != tt.warns {
+				t.Errorf("warnings %q; want a data_dir warning: %v", cfg.Warnings(), tt.warns)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SampleConfig is the commented config.yaml written by `watchtower init`.
+// Every field Load understands is listed with its default, so keep it in
+// step with the Config struct.
+const SampleConfig = `# Watchtower configuration
+# Location: ~/.config/watchtower/config.yaml
+
+# ── AI brief ──────────────────────────────────────────────────────────────────
+# Provider for the intel brief: groq, openai, deepseek, gemini, claude,
+# openrouter (many models behind one key), azure (Azure OpenAI; see azure
+# below) or local (an OpenAI-compatible server such as Ollama on
+# localhost:11434).
+llm_provider: groq
+# API key for the provider. Can also be set via the LLM_API_KEY environment
+# variable. Leave empty to get a heuristic (no AI) brief.
+llm_api_key: ""
+# Alternatively, a command whose output is the key, e.g. from a password
+# manager: "pass show watchtower/groq" or "op read op://Private/groq/key".
+# It runs at startup and takes precedence over llm_api_key.
+llm_api_key_command: ""
+# Model name; empty uses the provider default (e.g. llama-3.1-8b-instant on groq).
+# OpenRouter models are namespaced vendor/model, e.g. anthropic/claude-3-haiku.
+llm_model: ""
+# Azure OpenAI deployment for llm_provider: azure. The key goes in
+# llm_api_key as usual; api_version empty uses 2024-06-01.
+azure:
+  endpoint: ""
+  deployment: ""
+  api_version: ""
+# Backup providers tried in order when llm_provider fails (rate limit, outage).
+llm_fallbacks: []
+#   - provider: openai
+#     api_key: sk-...
+#     model: gpt-4o-mini
+# Extra headers sent with every LLM request, e.g. for a corporate gateway.
+llm_headers: {}
+#   X-Tenant-Id: acme
+# Route a provider through a gateway or proxy: endpoint replaces its URL,
+# auth_header/auth_prefix replace how the key is sent (the prefix is only
+# used with auth_header). Unset fields keep the built-in defaults.
+llm_overrides: {}
+#   openai:
+#     endpoint: https://llm-gateway.example.com/v1/chat/completions
+#     auth_header: X-Gateway-Key
+#     auth_prefix: ""
+# How long a generated brief is reused before asking the LLM again.
+brief_cache_minutes: 60
+# How many of the top headlines (by severity) the brief prompt includes.
+# More gives the model context on busy days; fewer is cheaper. Max 100.
+brief_headline_count: 40
+# Show "stale — press B to refresh" once a cached brief is within this
+# percentage of brief_cache_minutes from expiring (-1 disables).
+brief_stale_percent: 10
+# Add a one-line summary of your local weather to the global brief prompt,
+# so the model can note when a regional disaster is relevant.
+brief_include_weather: false
+# When the brief is generated without pressing b: news (as soon as headlines
+# load), overview (the first time the Overview tab is shown) or manual (only
+# on b). A cached brief is always shown; this only decides when tokens are spent.
+brief_trigger: news
+# A cached brief older than this many minutes is still shown at once, but a
+# fresh one is generated in the background and replaces it (0 = off, e.g. 20).
+brief_soft_refresh_minutes: 0
+# Language the brief is written in, e.g. French or Japanese, whatever the
+# language of the headlines. Empty writes it in English. Section headers and
+# country names stay English; press B once after changing it.
+brief_language: ""
+# Optional brief sections; disabled ones are not requested (saving tokens)
+# and not shown. The summary is always included.
+brief_sections:
+  threats: true
+  country_risks: true
+# Lines each key threat may take in the brief panel before it is cut short
+# with "…": overview on the dashboard, focus in focus mode (f). 0 wraps the
+# whole threat; overview: 1 keeps a narrow quadrant compact.
+threat_lines:
+  overview: 0
+  focus: 0
+# Local tab brief: summary (a short digest of local news and weather) or
+# safety (today's commute, weather hazards and unrest as a to-do list).
+# Toggled with S; each is cached separately.
+local_brief_mode: summary
+
+# ── Location & weather ────────────────────────────────────────────────────────
+location:
+  city: London
+  # ISO 3166-1 alpha-2 country code, used for local news and geocoding.
+  country: GB
+  # Leave both at 0 to geocode the city on first start; the result is saved here.
+  latitude: 0
+  longitude: 0
+# Primary weather source: open-meteo or wttr (wttr.in); the other is the
+# fallback when it fails. wttr.in forecasts three days.
+weather_provider: open-meteo
+# celsius or fahrenheit
+temp_unit: celsius
+# Wind speed: kmh, mph, ms (m/s) or knots. Visibility: km or mi.
+wind_unit: kmh
+visibility_unit: km
+# Language for weather descriptions and threat labels: en, es, de or pt.
+language: en
+# One-line clothing hint under the current conditions.
+weather_advice: true
+# Today's sunrise, sunset and the moon phase under the current conditions.
+weather_astro: false
+
+# ── Refresh ───────────────────────────────────────────────────────────────────
+# Auto-refresh interval for all panels.
+refresh_seconds: 120
+# Per-section intervals; 0 uses refresh_seconds. E.g. crypto every 30s and
+# weather every 1800s.
+news_refresh_seconds: 0
+crypto_refresh_seconds: 0
+markets_refresh_seconds: 0
+weather_refresh_seconds: 0
+# Overall deadline for one refresh; panels still waiting after this show a
+# timeout error instead of spinning forever.
+refresh_timeout_seconds: 45
+# Refresh when the terminal regains focus and the data is older than this
+# many seconds (0 = off). Needs a terminal with focus reporting (xterm,
+# iTerm2, kitty, WezTerm, tmux with focus-events on); others ignore it.
+refresh_on_focus_seconds: 0
+# A refresh timer firing this many seconds later than due means the machine
+# was asleep: everything is refreshed at once and the timers restarted
+# (-1 disables).
+resume_gap_seconds: 60
+# Daily local-time window (HH:MM) during which auto-refresh is paused.
+# The window may wrap past midnight. Leave empty to disable.
+quiet_hours:
+  start: ""
+  end: ""
+
+# ── News ──────────────────────────────────────────────────────────────────────
+# Headlines whose title contains any of these keywords are pinned to the top.
+pinned_topics: []
+# Never show these sources (by name, e.g. Politico) or headlines containing
+# these keywords (case-insensitive, e.g. sponsored, horoscope).
+muted_sources: []
+muted_keywords: []
+# Extra local news feeds, e.g. your city paper. augment adds them to Google
+# News; replace uses only these.
+local_feeds: []
+#   - name: Evening Standard
+#     url: https://www.standard.co.uk/news/london/rss
+local_feeds_mode: augment
+# Local stories that also appear in Global News: off (show as usual), dim
+# (muted, tagged "also in Global") or hide.
+cross_dedup: off
+# Rank Global News by relevance to you: headlines naming location.city, its
+# country or one of these keywords are listed first (boost) or are all that
+# is shown (only). off keeps the tab genuinely global.
+relevance:
+  mode: off
+  keywords: []
+# Language and region of the Google News local feeds (hl/gl). Empty region
+# uses location.country; e.g. language ja, region JP for Japanese coverage.
+google_news:
+  language: en
+  region: ""
+# Also rate threat levels from the start of each item's summary, not only the
+# title. Catches neutral headlines over critical stories, at the cost of
+# some false positives.
+classify_summaries: false
+# Country risk panel above the Global News list; R toggles it and saves here.
+news_risk_panel: true
+# Show sources as short colored tags (REU, BBC, AJ) instead of full names.
+source_tags: false
+# Show "(5 sources)" next to stories that several feeds ran, merged into one.
+source_counts: true
+# Critical items younger than this many minutes flash as BREAKING; -1 disables.
+breaking_minutes: 15
+# What Enter does on an article: browser, reader (in-terminal) or copy (URL).
+enter_action: browser
+# Lines of the feed's summary shown under an article expanded with e.
+description_lines: 4
+# Command used to open links; %u is replaced by the URL (appended if absent).
+# Empty uses the system default (xdg-open / open / start).
+browser_command: ""
+
+# Optional endpoint of your own returning a JSON array of alerts:
+#   [{"title": "...", "url": "...", "severity": "critical", "time": "2024-05-01T12:00:00Z"}]
+# Polled on every refresh; alerts are listed above the news, tagged ALERT.
+# severity: critical, high, medium, low or info (P1–P4 / sev1–sev4 also work).
+webhook_feed_url: ""
+# Extra request headers for the alerts endpoint, e.g. a bearer token.
+webhook_feed_headers: {}
+#   Authorization: Bearer <token>
+
+# ── Markets ───────────────────────────────────────────────────────────────────
+# CoinGecko ids of the coins to track.
+crypto_pairs:
+  - bitcoin
+  - ethereum
+  - dogecoin
+  - usd-coin
+# Primary crypto price source: coingecko or binance (the other is the fallback).
+crypto_provider: coingecko
+# Optional CoinGecko API key for higher rate limits. Demo keys use the free
+# public host; set coingecko_pro: true for a paid plan key (pro host).
+coingecko_api_key: ""
+coingecko_pro: false
+# Trend column on the Markets tab: a sparkline of the prices watchtower saw
+# over the last N refreshes (-1 disables). Persisting keeps it across restarts.
+price_history_size: 12
+price_history_persist: false
+# Prices older than this many minutes while their market is open (crypto:
+# always) are marked stale on the Markets tab (-1 disables).
+market_stale_minutes: 15
+# ISO currency commodity prices are converted to (via a live USD FX rate).
+base_currency: USD
+# Commodity units: us (bbl, troy oz, lb) or metric (bbl, g, kg).
+commodity_units: us
+
+# Percentage moves smaller than this are shown muted instead of green/red
+# (0 colors every move).
+market_change_threshold: 0
+# Moves at or beyond this percentage are emphasised; 0 disables.
+market_change_strong: 0
+
+# ── Layout ────────────────────────────────────────────────────────────────────
+# Loading spinner: line, dot, minidot, jump, pulse, points, globe, moon,
+# monkey, meter, hamburger or ellipsis. Try line if dots render poorly.
+spinner_style: dot
+# Hex color for titles, sources, the active tab and the spinner; empty keeps
+# the default blue (#58a6ff).
+accent_color: ""
+# Use fixed-width ASCII (CLR, RN, SN…) instead of emoji for weather and news
+# markers, for terminals where emoji misalign tables or render as boxes.
+ascii_icons: false
+# Ask "Quit? (y/n)" before q or ctrl+c exits; pressing ctrl+c twice always quits.
+confirm_quit: false
+# Start in the glance view: just the threat gauge, critical count, highest
+# risk country and biggest market move, for a small pane or a wall display.
+# w toggles it and saves here.
+glance_view: false
+# Cap the layout width on very wide terminals and center it; 0 = unlimited.
+max_content_width: 0
+# Below this many columns the header shrinks to "🌍 WT" and a status
+# indicator; -1 always shows the full header.
+compact_header_width: 80
+
+# ── Cache ─────────────────────────────────────────────────────────────────────
+# Where cached briefs and the risk and price history are kept, e.g. a synced
+# folder (absolute or ~/ path). Empty uses ~/.cache/watchtower.
+data_dir: ""
+# Housekeeping of data_dir at startup: cached briefs untouched for this many
+# days are deleted, then the oldest go until everything fits in cache_max_mb,
+# trimming the oldest risk history rows if that alone is too big (-1
+# disables either). The price history is capped by its own size.
+cache_max_age_days: 180
+cache_max_mb: 50
+`
+
+// WriteSample writes SampleConfig to the config path and returns the path.
+// An existing config is only replaced when force is set.
+func WriteSample(force bool) (string, error) {
+	cfgFile, err := Path()
+	if err != nil {
+		return "", fmt.Errorf("getting home dir: %w", err)
+	}
+	if !force && ConfigExists() {
+		return cfgFile, fmt.Errorf("config already exists at %s (use --force to overwrite)", cfgFile)
+	}
+	if err := os.MkdirAll(filepath.Dir(cfgFile), 0755); err != nil {
+		return "", fmt.Errorf("creating config dir: %w", err)
+	}
+	if err := os.WriteFile(cfgFile, []byte(SampleConfig), 0600); err != nil {
+		return "", fmt.Errorf("writing config: %w", err)
+	}
+	return cfgFile, restrictPerms(cfgFile)
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"watchtower/fixtures"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Settings the app changes at runtime (toggles, geocoded coordinates) are
+// written by editing just their values in config.yaml, so the comments,
+// blank lines and key order of a hand-edited or `watchtower init` file
+// survive. Scalars are replaced in the text itself; only shapes that can't
+// be (a missing nested key, a block value, a flow collection) go through a
+// yaml.v3 re-encode, which still keeps comments and order.
+
+// setting is one value to persist; key may be dotted ("location.latitude")
+type setting struct {
+	key   string
+	value interface{}
+}
+
+// saveSettings writes the settings into config.yaml in one pass. Nothing
+// is written in fixture mode, so demos never touch the real config.
+func saveSettings(settings ...setting) error {
+	if fixtures.Dir() != "" {
+		return nil
+	}
+	cfgFile, err := Path()
+	if err != nil {
+		return fmt.Errorf("getting home dir: %w", err)
+	}
+	data, err := os.ReadFile(cfgFile)
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	out, err := setYAML(data, settings...)
+	if err != nil {
+		return fmt.Errorf("updating config: %w", err)
+	}
+	if err := os.WriteFile(cfgFile, out, 0600); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return restrictPerms(cfgFile)
+}
+
+// setYAML returns data with each setting's value replaced, or added at the
+// end of its mapping when missing. Everything else is kept as it was.
+func setYAML(data []byte, settings ...setting) ([]byte, error) {
+	for _, s := range settings {
+		var err error
+		if data, err = setOne(data, s); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.key, err)
+		}
+	}
+	return data, nil
+}
+
+// setOne applies a single setting to data
+func setOne(data []byte, s setting) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 { // empty file
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("top level is not a mapping")
+	}
+	var value yaml.Node
+	if err := value.Encode(s.value); err != nil {
+		return nil, err
+	}
+	path := strings.Split(s.key, ".")
+
+	if old, flow := lookupNode(doc.Content[0], path); old != nil {
+		// In a flow collection ("location: {city: Lisbon, country: PT}")
+		// the rest of the line holds sibling values, so only the re-encode
+		// can replace one
+		if !flow {
+			if out, ok := spliceScalar(data, old, &value); ok {
+				return out, nil
+			}
+		}
+	} else if text, ok := scalarText(&value); ok && len(path) == 1 {
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			data = append(data, '\n')
+		}
+		return append(data, s.key+": "+text+"\n"...), nil
+	}
+
+	if err := setNode(doc.Content[0], path, &value); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// lookupNode returns the value at path in mapping m, or nil; flow reports
+// whether it sits inside a flow-style mapping
+func lookupNode(m *yaml.Node, path []string) (v *yaml.Node, flow bool) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value != path[0] {
+			continue
+		}
+		v := m.Content[i+1]
+		if len(path) == 1 {
+			return v, m.Style&yaml.FlowStyle != 0
+		}
+		if v.Kind != yaml.MappingNode {
+			return nil, false
+		}
+		v, flow := lookupNode(v, path[1:])
+		return v, flow || m.Style&yaml.FlowStyle != 0
+	}
+	return nil, false
+}
+
+// scalarText renders a scalar value as it would appear after "key: ",
+// false for anything that needs more than one line
+func scalarText(v *yaml.Node) (string, bool) {
+	if v.Kind != yaml.ScalarNode {
+		return "", false
+	}
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", false
+	}
+	text := strings.TrimSuffix(string(b), "\n")
+	return text, !strings.Contains(text, "\n")
+}
+
+// spliceScalar replaces the one-line scalar old with value in the text of
+// data, keeping whatever follows it on the line (spacing and a comment)
+func spliceScalar(data []byte, old, value *yaml.Node) ([]byte, bool) {
+	if old.Kind != yaml.ScalarNode || old.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 ||
+		(old.Tag == "!!null" && old.Value == "") {
+		return nil, false
+	}
+	text, ok := scalarText(value)
+	if !ok {
+		return nil, false
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if old.Line < 1 || old.Line > len(lines) {
+		return nil, false
+	}
+	line := lines[old.Line-1]
+	body := strings.TrimRight(line, "\r\n")
+	eol := line[len(body):]
+	runes := []rune(body) // yaml columns count characters, not bytes
+	col := old.Column - 1
+	if col < 0 || col > len(runes) {
+		return nil, false
+	}
+	rest, tail := string(runes[col:]), ""
+	if old.LineComment != "" {
+		i := strings.LastIndex(rest, old.LineComment)
+		if i < 0 {
+			return nil, false
+		}
+		rest, tail = rest[:i], rest[i:]
+	}
+	trimmed := strings.TrimRight(rest, " \t")
+	lines[old.Line-1] = string(runes[:col]) + text + rest[len(trimmed):] + tail + eol
+	return []byte(strings.Join(lines, "")), true
+}
+
+// setNode sets path in mapping m to value, creating intermediate mappings.
+// A replaced value keeps its line comment ("key: value  # note").
+func setNode(m *yaml.Node, path []string, value *yaml.Node) error {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		k, v := m.Content[i], m.Content[i+1]
+		if k.Value != path[0] {
+			continue
+		}
+		if len(path) > 1 {
+			if v.Kind != yaml.MappingNode {
+				return fmt.Errorf("%s is not a mapping", path[0])
+			}
+			return setNode(v, path[1:], value)
+		}
+		value.LineComment = v.LineComment
+		m.Content[i+1] = value
+		return nil
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}
+	if len(path) > 1 {
+		child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		m.Content = append(m.Content, key, child)
+		return setNode(child, path[1:], value)
+	}
+	m.Content = append(m.Content, key, value)
+	return nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"watchtower/fixtures"
+)
+
+func TestSetYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		settings []setting
+		want     string
+	}{
+		{
+			"keeps comments and blank lines",
+			"# header\n\n# panel toggle\nnews_risk_panel: true  # R toggles\n\nother: 1\n",
+			[]setting{{"news_risk_panel", false}},
+			"# header\n\n# panel toggle\nnews_risk_panel: false  # R toggles\n\nother: 1\n",
+		},
+		{
+			"nested keys",
+			"location:\n  # where you are\n  city: Lisbon\n  latitude: 0\n  longitude: 0\n",
+			[]setting{{"location.latitude", 38.72}, {"location.longitude", -9.14}},
+			"location:\n  # where you are\n  city: Lisbon\n  latitude: 38.72\n  longitude: -9.14\n",
+		},
+		{
+			"quoted strings",
+			"local_brief_mode: \"summary\"\nname: x\n",
+			[]setting{{"local_brief_mode", "safety"}},
+			"local_brief_mode: safety\nname: x\n",
+		},
+		{
+			"missing key appended",
+			"a: 1",
+			[]setting{{"glance_view", true}},
+			"a: 1\nglance_view: true\n",
+		},
+		{
+			"missing nested key",
+			"# where you are\nlocation:\n  city: Lisbon\n",
+			[]setting{{"location.latitude", 38.72}},
+			"# where you are\nlocation:\n  city: Lisbon\n  latitude: 38.72\n",
+		},
+		{
+			"flow mapping",
+			"# where you are\nlocation: {city: Lisbon, country: PT}\nother: 1\n",
+			[]setting{{"location.city", "Porto"}},
+			"# where you are\nlocation: {city: Porto, country: PT}\nother: 1\n",
+		},
+		{
+			"empty file",
+			"",
+			[]setting{{"glance_view", true}},
+			"glance_view: true\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setYAML([]byte(tt.in), tt.settings...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSetYAMLSample checks a `watchtower init` config only changes on the
+// lines of the settings written
+func TestSetYAMLSample(t *testing.T) {
+	got, err := setYAML([]byte(SampleConfig),
+		setting{"news_risk_panel", false},
+		setting{"location.latitude", 38.72},
+		setting{"location.longitude", -9.14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := strings.Split(SampleConfig, "\n")
+	after := strings.Split(string(got), "\n")
+	if len(before) != len(after) {
+		t.Fatalf("line count changed from %d to %d", len(before), len(after))
+	}
+	var changed []string
+	for i := range before {
+		if before[i] != after[i] {
+			changed = append(changed, after[i])
+		}
+	}
+	want := []string{"  latitude: 38.72", "  longitude: -9.14", "news_risk_panel: false"}
+	if strings.Join(changed, "|") != strings.Join(want, "|") {
+		t.Errorf("changed lines = %q, want %q", changed, want)
+	}
+}
+
+func TestSaveSettingFixtureMode(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".config", "watchtower", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	const orig = "# mine\nglance_view: false\n"
+	if err := os.WriteFile(path, []byte(orig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(fixtures.EnvVar, t.TempDir())
+	if err := SaveSetting("glance_view", true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != orig {
+		t.Errorf("fixture mode wrote the config:\n%s", data)
+	}
+
+	t.Setenv(fixtures.EnvVar, "")
+	if err := SaveSetting("glance_view", true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "# mine\nglance_view: true\n" {
+		t.Errorf("config after SaveSetting:\n%s", data)
+	}
+}
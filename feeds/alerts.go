@@ -0,0 +1,120 @@
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"watchtower/fixtures"
+)
+
+// AlertSource is the source tag shown on items from the webhook feed
+const AlertSource = "ALERT"
+
+// webhookAlert is one entry of the webhook feed's JSON array
+type webhookAlert struct {
+	Title    string    `json:"title"`
+	URL      string    `json:"url"`
+	Severity string    `json:"severity"`
+	Time     time.Time `json:"time"` // RFC 3339
+}
+
+// FetchAlerts polls a user-run endpoint returning a JSON array of
+// {title, url, severity, time} and converts the entries to news items.
+// headers are sent as-is, e.g. for an Authorization token.
+func FetchAlerts(ctx context.Context, url string, headers map[string]string) ([]NewsItem, error) {
+	var raw []webhookAlert
+	if ok, err := fixtures.Load("alerts", &raw); ok {
+		if err != nil {
+			return nil, err
+		}
+		return alertItems(raw), nil
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating alerts request: %w", err)
+	}
+	req.Header.Set("User-Agent", feedUserAgent)
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("alerts request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("alerts feed HTTP %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding alerts: %w", err)
+	}
+	return alertItems(raw), nil
+}
+
+// alertItems converts decoded alerts to news items, most severe first
+func alertItems(raw []webhookAlert) []NewsItem {
+	var items []NewsItem
+	for _, a := range raw {
+		if strings.TrimSpace(a.Title) == "" {
+			continue
+		}
+		pub := a.Time
+		if pub.IsZero() {
+			pub = time.Now()
+		}
+		items = append(items, NewsItem{
+			Title:       a.Title,
+			Source:      AlertSource,
+			Published:   pub,
+			URL:         a.URL,
+			ThreatLevel: ParseThreatLevel(a.Severity),
+			Category:    "alert",
+			IsAlert:     true,
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].ThreatLevel != items[j].ThreatLevel {
+			return items[i].ThreatLevel > items[j].ThreatLevel
+		}
+		return items[i].Published.After(items[j].Published)
+	})
+	return items
+}
+
+// ParseThreatLevel maps common severity names (critical, high/error,
+// medium/warning, low, info; also P1–P4 and sev1–sev4) to a ThreatLevel.
+// Unknown strings map to ThreatInfo.
+func ParseThreatLevel(s string) ThreatLevel {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "critical", "crit", "fatal", "emergency", "p1", "sev1":
+		return ThreatCritical
+	case "high", "error", "major", "p2", "sev2":
+		return ThreatHigh
+	case "medium", "moderate", "warning", "warn", "p3", "sev3":
+		return ThreatMedium
+	case "low", "minor", "p4", "sev4":
+		return ThreatLow
+	default:
+		return ThreatInfo
+	}
+}
+
+// MergeAlerts puts webhook alerts above the feed news. The result is a new
+// slice; news is not modified.
+func MergeAlerts(alerts, news []NewsItem) []NewsItem {
+	if len(alerts) == 0 {
+		return news
+	}
+	merged := make([]NewsItem, 0, len(alerts)+len(news))
+	merged = append(merged, alerts...)
+	return append(merged, news...)
+}
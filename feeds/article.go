@@ -0,0 +1,86 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// maxArticleBytes caps how much of a page the reader will download
+const maxArticleBytes = 5 << 20
+
+// FetchArticleText downloads an article page and extracts its readable
+// paragraphs for the in-terminal reader. Paragraphs are separated by a
+// blank line.
+func FetchArticleText(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (watchtower reader)")
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("article request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("article HTTP %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, maxArticleBytes))
+	if err != nil {
+		return "", fmt.Errorf("parsing article: %w", err)
+	}
+
+	// Prefer the semantic article body; fall back to every paragraph on the page
+	var paras []string
+	for _, sel := range []string{"article p", "main p", "p"} {
+		doc.Find(sel).Each(func(_ int, s *goquery.Selection) {
+			text := strings.Join(strings.Fields(s.Text()), " ")
+			if len(text) >= 40 {
+				paras = append(paras, text)
+			}
+		})
+		if len(paras) > 0 {
+			break
+		}
+	}
+	if len(paras) == 0 {
+		return "", fmt.Errorf("no readable text found")
+	}
+	return strings.Join(paras, "\n\n"), nil
+}
+
+// maxDescriptionRunes caps the summary kept per item; full entry content
+// can be a whole article.
+const maxDescriptionRunes = 600
+
+// plainText strips the HTML from a feed entry's description and collapses
+// its whitespace. Descriptions that merely repeat the title are dropped.
+func plainText(desc, title string) string {
+	if strings.TrimSpace(desc) == "" {
+		return ""
+	}
+	text := desc
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(desc)); err == nil {
+		text = doc.Text()
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if strings.EqualFold(text, strings.TrimSpace(title)) {
+		return ""
+	}
+	if runes := []rune(text); len(runes) > maxDescriptionRunes {
+		text = string(runes[:maxDescriptionRunes-1]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,131 @@
+package feeds
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Different outlets rarely share a headline word for word, so clustering
+// compares the sets of meaningful words instead: two titles are the same
+// story when enough of their words overlap. Words are cut to their first
+// five letters, a crude stem that lets "Russia"/"Russian" and
+// "attack"/"attacks" match.
+
+const (
+	clusterMinShared = 3   // words two headlines must share
+	clusterMinJacc   = 0.4 // shared words / all words of both headlines
+	stemLen          = 5
+)
+
+// stopWords carry no story identity
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "that": true,
+	"this": true, "after": true, "over": true, "into": true, "amid": true, "says": true,
+	"said": true, "will": true, "has": true, "have": true, "its": true, "are": true,
+	"was": true, "were": true, "new": true, "not": true, "but": true, "who": true,
+	"what": true, "how": true, "why": true, "out": true, "about": true, "more": true,
+}
+
+// titleStems returns the stemmed meaningful words of a headline, without
+// the " - Publisher" suffix Google News appends
+func titleStems(title string) map[string]bool {
+	if i := strings.LastIndex(title, " - "); i > 0 {
+		title = title[:i]
+	}
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	stems := make(map[string]bool, len(words))
+	for _, w := range words {
+		if len([]rune(w)) < 3 || stopWords[w] {
+			continue
+		}
+		if r := []rune(w); len(r) > stemLen {
+			w = string(r[:stemLen])
+		}
+		stems[w] = true
+	}
+	return stems
+}
+
+// sameStory reports whether two stem sets describe the same story
+func sameStory(a, b map[string]bool) bool {
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	return shared >= clusterMinShared && union > 0 && float64(shared)/float64(union) >= clusterMinJacc
+}
+
+// Clusters groups items that report the same story and returns the groups
+// covered by at least two sources, most sources first. Items keep their
+// order within a group, and an item joins a group if it matches any member.
+// The Duplicates dedup merged into an item are grouped with it, so
+// syndicated copies of one headline still show each outlet's version.
+func Clusters(items []NewsItem) [][]NewsItem {
+	items = withDuplicates(items)
+	stems := make([]map[string]bool, len(items))
+	for i, item := range items {
+		stems[i] = titleStems(item.Title)
+	}
+
+	var groups [][]int
+	for i := range items {
+		joined := false
+		for g, members := range groups {
+			for _, j := range members {
+				if sameStory(stems[i], stems[j]) {
+					groups[g] = append(groups[g], i)
+					joined = true
+					break
+				}
+			}
+			if joined {
+				break
+			}
+		}
+		if !joined {
+			groups = append(groups, []int{i})
+		}
+	}
+
+	var clusters [][]NewsItem
+	for _, members := range groups {
+		cluster := make([]NewsItem, 0, len(members))
+		for _, j := range members {
+			cluster = append(cluster, items[j])
+		}
+		if ClusterSources(cluster) >= 2 {
+			clusters = append(clusters, cluster)
+		}
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return ClusterSources(clusters[i]) > ClusterSources(clusters[j])
+	})
+	return clusters
+}
+
+// withDuplicates lists each item followed by its merged duplicates
+func withDuplicates(items []NewsItem) []NewsItem {
+	var out []NewsItem
+	for _, item := range items {
+		dups := item.Duplicates
+		item.Duplicates = nil
+		out = append(out, item)
+		out = append(out, dups...)
+	}
+	return out
+}
+
+// ClusterSources counts the distinct sources in a cluster
+func ClusterSources(cluster []NewsItem) int {
+	sources := map[string]bool{}
+	for _, item := range cluster {
+		sources[item.Source] = true
+	}
+	return len(sources)
+}
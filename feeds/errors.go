@@ -0,0 +1,64 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// FailureKind says why a feed source failed
+type FailureKind string
+
+const (
+	FailTimeout FailureKind = "timeout"
+	FailDNS     FailureKind = "dns"
+	FailHTTP    FailureKind = "http"
+	FailParse   FailureKind = "parse"
+	FailNetwork FailureKind = "network"
+)
+
+// SourceError records why one feed source contributed nothing
+type SourceError struct {
+	Source string
+	URL    string
+	Kind   FailureKind
+	Err    error
+}
+
+func (e SourceError) Error() string {
+	return fmt.Sprintf("%s (%s): %v", e.Source, e.Kind, e.Err)
+}
+
+func (e SourceError) Unwrap() error {
+	return e.Err
+}
+
+// statusError is a non-200 HTTP response
+type statusError struct{ code int }
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("HTTP %d", e.code)
+}
+
+// errNotFeed marks a response that arrived fine but isn't a usable feed
+var errNotFeed = errors.New("not a feed")
+
+// classifyError maps a fetchFeed error to its FailureKind
+func classifyError(err error) FailureKind {
+	var se statusError
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &se):
+		return FailHTTP
+	case errors.Is(err, errNotFeed):
+		return FailParse
+	case errors.As(err, &dnsErr):
+		return FailDNS
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return FailTimeout
+	default:
+		return FailNetwork
+	}
+}
@@ -1,13 +1,18 @@
 package feeds
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"watchtower/fixtures"
+
 	"github.com/mmcdole/gofeed"
 )
 
@@ -46,6 +51,41 @@ type NewsItem struct {
 	ThreatLevel ThreatLevel
 	Category    string
 	IsLocal     bool
+	Pinned      bool       // title matches one of the user's pinned topics
+	IsAlert     bool       // from the user's webhook alerts feed, not RSS
+	Description string     // plain-text summary from the feed entry, if any
+	SourceCount int        // distinct sources that ran this story before dedup merged them; 0 or 1 = just this one
+	Duplicates  []NewsItem // other sources' copies dedup merged into this item, one per source
+}
+
+// Options tunes how fetched items are filtered and ordered
+type Options struct {
+	PinnedTopics  []string // case-insensitive title terms floated to the top
+	MutedSources  []string // source names never fetched or shown
+	MutedKeywords []string // case-insensitive title terms whose items are dropped
+	ScanSummaries bool     // also classify threats from the start of each entry's description
+}
+
+// lowerTerms lowercases and trims terms, dropping empty ones
+func lowerTerms(terms []string) []string {
+	var out []string
+	for _, t := range terms {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// containsAny reports whether s contains any of the (lowercased) terms
+func containsAny(s string, terms []string) bool {
+	s = strings.ToLower(s)
+	for _, t := range terms {
+		if strings.Contains(s, t) {
+			return true
+		}
+	}
+	return false
 }
 
 // GlobalFeeds are world news RSS sources
@@ -63,14 +103,41 @@ var GlobalFeeds = []struct {
 	{"Foreign Policy", "https://foreignpolicy.com/feed/"},
 }
 
-// LocalFeedURLs generates geo-targeted RSS feeds based on city/country
-func LocalFeedURLs(city, country string) []struct{ Name, URL string } {
-	return []struct{ Name, URL string }{
-		{"Google News Local", fmt.Sprintf("https://news.google.com/rss/search?q=%s+news&hl=en&gl=%s&ceid=%s:en",
-			strings.ReplaceAll(city, " ", "+"), country, country)},
-		{"Google News Country", fmt.Sprintf("https://news.google.com/rss/headlines/section/geo/%s",
-			strings.ReplaceAll(city, " ", "%20"))},
+// LocalSources chooses where local news comes from: Google News built from
+// the location, the user's own feeds, or both.
+type LocalSources struct {
+	City     string
+	Country  string
+	Feeds    []struct{ Name, URL string } // user-supplied local feeds
+	Replace  bool                         // use only Feeds, without Google News
+	Language string                       // Google News hl, e.g. "ja"; "" = en
+	Region   string                       // Google News gl; "" = Country
+}
+
+// LocalFeedURLs lists the local feeds to fetch. Google News is built from
+// the city and country unless Replace is set with feeds of its own.
+func LocalFeedURLs(src LocalSources) []struct{ Name, URL string } {
+	if src.Replace && len(src.Feeds) > 0 {
+		return src.Feeds
+	}
+	hl, gl := src.Language, src.Region
+	if hl == "" {
+		hl = "en"
+	}
+	if gl == "" {
+		gl = src.Country
 	}
+	geo := fmt.Sprintf("https://news.google.com/rss/headlines/section/geo/%s",
+		strings.ReplaceAll(src.City, " ", "%20"))
+	if src.Language != "" || src.Region != "" {
+		geo += fmt.Sprintf("?hl=%s&gl=%s&ceid=%s:%s", hl, gl, gl, hl)
+	}
+	urls := []struct{ Name, URL string }{
+		{"Google News Local", fmt.Sprintf("https://news.google.com/rss/search?q=%s+news&hl=%s&gl=%s&ceid=%s:%s",
+			strings.ReplaceAll(src.City, " ", "+"), hl, gl, gl, hl)},
+		{"Google News Country", geo},
+	}
+	return append(urls, src.Feeds...)
 }
 
 // keyword threat classifier
@@ -126,24 +193,123 @@ func classifyThreat(title string) (ThreatLevel, string) {
 	return ThreatInfo, "general"
 }
 
-// FetchGlobalNews fetches and classifies global news items
-func FetchGlobalNews(ctx context.Context) ([]NewsItem, error) {
-	return fetchFeeds(ctx, GlobalFeeds, false)
+// summaryScanRunes is how much of a description classifyWithSummary reads;
+// the lede carries the news, the rest mostly adds false positives.
+const summaryScanRunes = 300
+
+// classifyWithSummary classifies from the title and the start of the
+// description. The title wins ties, and a description only counts at full
+// weight when two different keywords of a tier appear in it; a single
+// keyword there rates one level lower.
+func classifyWithSummary(title, desc string) (ThreatLevel, string) {
+	level, cat := classifyThreat(title)
+	if runes := []rune(desc); len(runes) > summaryScanRunes {
+		desc = string(runes[:summaryScanRunes])
+	}
+	lower := strings.ToLower(desc)
+	for _, tier := range threatKeywords {
+		if tier.level <= level {
+			break
+		}
+		hits := 0
+		for _, kw := range tier.words {
+			if strings.Contains(lower, kw) {
+				hits++
+			}
+		}
+		switch {
+		case hits >= 2:
+			return tier.level, tier.category
+		case hits == 1 && tier.level-1 > level:
+			return tier.level - 1, tier.category
+		}
+	}
+	return level, cat
+}
+
+// FetchGlobalNews fetches and classifies global news items. Sources that
+// failed are returned alongside the items; err is set only if all failed.
+func FetchGlobalNews(ctx context.Context, opts Options) ([]NewsItem, []SourceError, error) {
+	if items, ok, err := fixtureNews("global_news", false, opts); ok {
+		return items, nil, err
+	}
+	return fetchFeeds(ctx, GlobalFeeds, false, opts)
 }
 
 // FetchLocalNews fetches geo-targeted news items
-func FetchLocalNews(ctx context.Context, city, country string) ([]NewsItem, error) {
-	return fetchFeeds(ctx, LocalFeedURLs(city, country), true)
+func FetchLocalNews(ctx context.Context, src LocalSources, opts Options) ([]NewsItem, []SourceError, error) {
+	if items, ok, err := fixtureNews("local_news", true, opts); ok {
+		return items, nil, err
+	}
+	return fetchFeeds(ctx, LocalFeedURLs(src), true, opts)
+}
+
+const feedUserAgent = "watchtower/1.0 (Go RSS reader)"
+
+// fetchFeed downloads and parses one feed, rejecting responses that are
+// not really feeds — e.g. a proxy's HTML error page served with 200, which
+// gofeed would otherwise turn into garbage or zero items without an error.
+func fetchFeed(ctx context.Context, url string) (*gofeed.Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", feedUserAgent)
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, statusError{resp.StatusCode}
+	}
+
+	body := bufio.NewReader(resp.Body)
+	if strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+		// Some servers label real feeds text/html, so only reject when the
+		// body actually looks like an HTML page.
+		head, _ := body.Peek(512)
+		lower := strings.ToLower(strings.TrimSpace(string(head)))
+		if strings.HasPrefix(lower, "<!doctype html") || strings.HasPrefix(lower, "<html") {
+			return nil, fmt.Errorf("%w: returned an HTML page", errNotFeed)
+		}
+	}
+
+	feed, err := gofeed.NewParser().Parse(body)
+	if err != nil {
+		// A deadline hit mid-body surfaces as a read error from the parser
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %v", errNotFeed, err)
+	}
+	if strings.TrimSpace(feed.Title) == "" || len(feed.Items) == 0 {
+		return nil, fmt.Errorf("%w: empty or invalid (title %q, %d items)", errNotFeed, feed.Title, len(feed.Items))
+	}
+	return feed, nil
 }
 
-func fetchFeeds(ctx context.Context, sources []struct{ Name, URL string }, isLocal bool) ([]NewsItem, error) {
-	fp := gofeed.NewParser()
-	fp.UserAgent = "watchtower/1.0 (Go RSS reader)"
+func fetchFeeds(ctx context.Context, sources []struct{ Name, URL string }, isLocal bool, opts Options) ([]NewsItem, []SourceError, error) {
+	// Muted sources aren't fetched at all, so they can't count as failures
+	if muted := lowerTerms(opts.MutedSources); len(muted) > 0 {
+		var kept []struct{ Name, URL string }
+		for _, src := range sources {
+			if !slices.Contains(muted, strings.ToLower(src.Name)) {
+				kept = append(kept, src)
+			}
+		}
+		sources = kept
+	}
+	mutedWords := lowerTerms(opts.MutedKeywords)
 
 	var (
-		mu    sync.Mutex
-		items []NewsItem
-		wg    sync.WaitGroup
+		mu     sync.Mutex
+		items  []NewsItem
+		failed []SourceError
+		wg     sync.WaitGroup
 	)
 
 	for _, src := range sources {
@@ -154,17 +320,30 @@ func fetchFeeds(ctx context.Context, sources []struct{ Name, URL string }, isLoc
 			fetchCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 			defer cancel()
 
-			feed, err := fp.ParseURLWithContext(url, fetchCtx)
-			if err != nil {
-				return
+			feed, err := fetchFeed(fetchCtx, url)
+			if err != nil && classifyError(err) == FailTimeout && ctx.Err() == nil {
+				// Timeouts are often transient; retry once, briefly
+				retryCtx, cancelRetry := context.WithTimeout(ctx, 5*time.Second)
+				feed, err = fetchFeed(retryCtx, url)
+				cancelRetry()
 			}
 
 			mu.Lock()
 			defer mu.Unlock()
 
+			if err != nil {
+				failed = append(failed, SourceError{Source: name, URL: url, Kind: classifyError(err), Err: err})
+				return
+			}
+
+			// Relative item links resolve against the site, else the feed itself
+			base := url
+			if feed.Link != "" {
+				base = feed.Link
+			}
 			cutoff := time.Now().Add(-24 * time.Hour)
 			for _, entry := range feed.Items {
-				if entry.Title == "" {
+				if entry.Title == "" || containsAny(entry.Title, mutedWords) {
 					continue
 				}
 				pub := time.Now()
@@ -176,11 +355,20 @@ func fetchFeeds(ctx context.Context, sources []struct{ Name, URL string }, isLoc
 				if pub.Before(cutoff) {
 					continue
 				}
+				desc := entry.Description
+				if desc == "" {
+					desc = entry.Content
+				}
+				desc = plainText(desc, entry.Title)
 				level, cat := classifyThreat(entry.Title)
-				link := ""
-				if entry.Link != "" {
-					link = entry.Link
+				if opts.ScanSummaries {
+					level, cat = classifyWithSummary(entry.Title, desc)
 				}
+				link := entry.Link
+				if strings.TrimSpace(link) == "" {
+					link = guidURL(entry.GUID)
+				}
+				link = normalizeURL(link, base)
 				items = append(items, NewsItem{
 					Title:       entry.Title,
 					Source:      name,
@@ -189,6 +377,7 @@ func fetchFeeds(ctx context.Context, sources []struct{ Name, URL string }, isLoc
 					ThreatLevel: level,
 					Category:    cat,
 					IsLocal:     isLocal,
+					Description: desc,
 				})
 			}
 		}(src.Name, src.URL)
@@ -196,7 +385,25 @@ func fetchFeeds(ctx context.Context, sources []struct{ Name, URL string }, isLoc
 
 	wg.Wait()
 
-	// Sort: critical first, then by time
+	// Report failures in source order so the list doesn't shuffle between refreshes
+	order := make(map[string]int, len(sources))
+	for i, src := range sources {
+		order[src.Name] = i
+	}
+	sort.Slice(failed, func(i, j int) bool { return order[failed[i].Source] < order[failed[j].Source] })
+
+	if len(failed) == len(sources) && len(sources) > 0 {
+		return nil, failed, fmt.Errorf("all %d feeds failed", len(sources))
+	}
+
+	return sortAndDedup(items, opts), failed, nil
+}
+
+// sortAndDedup orders items critical-first then newest-first, drops
+// near-duplicate titles and floats pinned topics to the top. Each kept
+// item's SourceCount records how many distinct sources carried it, and
+// Duplicates keeps the other sources' versions for comparing sources.
+func sortAndDedup(items []NewsItem, opts Options) []NewsItem {
 	sort.Slice(items, func(i, j int) bool {
 		if items[i].ThreatLevel != items[j].ThreatLevel {
 			return items[i].ThreatLevel > items[j].ThreatLevel
@@ -205,17 +412,114 @@ func fetchFeeds(ctx context.Context, sources []struct{ Name, URL string }, isLoc
 	})
 
 	// Deduplicate similar titles
-	seen := make(map[string]bool)
+	kept := make(map[string]int) // key → index in deduped
+	sources := make(map[string]map[string]bool)
 	var deduped []NewsItem
 	for _, item := range items {
 		key := strings.ToLower(item.Title[:min(40, len(item.Title))])
-		if !seen[key] {
-			seen[key] = true
+		i, ok := kept[key]
+		if !ok {
+			i = len(deduped)
+			kept[key] = i
+			sources[key] = make(map[string]bool)
 			deduped = append(deduped, item)
 		}
+		if ok && !sources[key][item.Source] {
+			deduped[i].Duplicates = append(deduped[i].Duplicates, item)
+		}
+		sources[key][item.Source] = true
+		deduped[i].SourceCount = len(sources[key])
 	}
 
-	return deduped, nil
+	return applyPinned(deduped, opts.PinnedTopics)
+}
+
+// StoryKey identifies a story across feeds for cross-list deduplication:
+// the lowercased start of the title, without the " - Publisher" suffix
+// Google News appends.
+func StoryKey(title string) string {
+	if i := strings.LastIndex(title, " - "); i > 0 {
+		title = title[:i]
+	}
+	runes := []rune(strings.ToLower(strings.Join(strings.Fields(title), " ")))
+	return string(runes[:min(40, len(runes))])
+}
+
+// fixtureItem is one headline in a news fixture. Ages are relative so
+// the canned items always look fresh.
+type fixtureItem struct {
+	Title       string
+	Source      string
+	URL         string
+	Description string
+	AgeMinutes  int
+}
+
+// fixtureNews serves the named news fixture when fixture mode is on,
+// classified, filtered and ordered like live items.
+func fixtureNews(name string, isLocal bool, opts Options) ([]NewsItem, bool, error) {
+	var raw []fixtureItem
+	ok, err := fixtures.Load(name, &raw)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	mutedSources := lowerTerms(opts.MutedSources)
+	mutedWords := lowerTerms(opts.MutedKeywords)
+	var items []NewsItem
+	for _, f := range raw {
+		if f.Title == "" || containsAny(f.Title, mutedWords) || slices.Contains(mutedSources, strings.ToLower(f.Source)) {
+			continue
+		}
+		level, cat := classifyThreat(f.Title)
+		if opts.ScanSummaries {
+			level, cat = classifyWithSummary(f.Title, f.Description)
+		}
+		items = append(items, NewsItem{
+			Title:       f.Title,
+			Source:      f.Source,
+			Published:   time.Now().Add(-time.Duration(f.AgeMinutes) * time.Minute),
+			URL:         f.URL,
+			ThreatLevel: level,
+			Category:    cat,
+			IsLocal:     isLocal,
+			Description: f.Description,
+		})
+	}
+	return sortAndDedup(items, opts), true, nil
+}
+
+// applyPinned marks items whose title mentions a pinned topic and moves
+// them above the rest, keeping the threat/time order within each group.
+func applyPinned(items []NewsItem, topics []string) []NewsItem {
+	if len(topics) == 0 {
+		return items
+	}
+	lowered := lowerTerms(topics)
+	for i := range items {
+		if containsAny(items[i].Title, lowered) {
+			items[i].Pinned = true
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Pinned && !items[j].Pinned
+	})
+	return items
+}
+
+// RankRelevant moves the items whose title match accepts up to just below
+// the alerts and pinned items, or with only drops the others (alerts and
+// pinned items always stay). Order is otherwise kept; items isn't modified.
+func RankRelevant(items []NewsItem, match func(title string) bool, only bool) []NewsItem {
+	ranked := make([]NewsItem, 0, len(items))
+	var rest []NewsItem
+	for _, item := range items {
+		if item.IsAlert || item.Pinned || match(item.Title) {
+			ranked = append(ranked, item)
+		} else if !only {
+			rest = append(rest, item)
+		}
+	}
+	return append(ranked, rest...)
 }
 
 func min(a, b int) int {
@@ -0,0 +1,129 @@
+package feeds
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClassifyWithSummary(t *testing.T) {
+	tests := []struct {
+		name      string
+		title     string
+		desc      string
+		wantLevel ThreatLevel
+		wantCat   string
+	}{
+		{
+			"neutral title, critical description",
+			"Officials comment on overnight events",
+			"A missile strike hit the capital as the invasion entered its third day.",
+			ThreatCritical, "conflict",
+		},
+		{
+			"single description keyword rates one level lower",
+			"Officials comment on overnight events",
+			"Reports of a coup in the capital could not be confirmed.",
+			ThreatHigh, "conflict",
+		},
+		{
+			"title wins ties",
+			"Election results announced",
+			"Crowds gathered for a protest outside parliament.",
+			ThreatMedium, "politics",
+		},
+		{
+			"downgraded description doesn't override a title of that level",
+			"Earthquake shakes coastal towns",
+			"The tremor came a day after the coup.",
+			ThreatHigh, "disaster",
+		},
+		{
+			"keywords past the scanned lede are ignored",
+			"Officials comment on overnight events",
+			strings.Repeat("Nothing happened. ", 20) + "Nuclear invasion.",
+			ThreatInfo, "general",
+		},
+		{"no description", "Markets open flat", "", ThreatInfo, "general"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, cat := classifyWithSummary(tt.title, tt.desc)
+			if level != tt.wantLevel || cat != tt.wantCat {
+				t.Errorf("classifyWithSummary(%q, %q) = %v, %q; want %v, %q",
+					tt.title, tt.desc, level, cat, tt.wantLevel, tt.wantCat)
+			}
+		})
+	}
+}
+
+func TestFetchFeedsGUIDFallback(t *testing.T) {
+	pub := time.Now().UTC().Format(time.RFC1123Z)
+	rss := `<?xml version="1.0"?>
+<rss version="2.0"><channel>
+<title>Test</title><link>https://example.com/</link>
+<item><title>GUID as URL</title><guid isPermaLink="true">https://example.com/news/guid-story?utm_source=rss</guid><pubDate>` + pub + `</pubDate></item>
+<item><title>Tag GUID</title><guid isPermaLink="false">tag:example.com,2024:story-7</guid><pubDate>` + pub + `</pubDate></item>
+<item><title>Numeric GUID</title><guid isPermaLink="false">123456</guid><pubDate>` + pub + `</pubDate></item>
+<item><title>Link wins</title><link>https://example.com/news/linked</link><guid>https://example.com/other</guid><pubDate>` + pub + `</pubDate></item>
+</channel></rss>`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		io.WriteString(w, rss)
+	}))
+	defer srv.Close()
+
+	items, failed, err := fetchFeeds(context.Background(), []struct{ Name, URL string }{{"Test", srv.URL}}, false, Options{})
+	if err != nil || len(failed) > 0 {
+		t.Fatalf("fetchFeeds: err %v, failed %v", err, failed)
+	}
+	want := map[string]string{
+		"GUID as URL":  "https://example.com/news/guid-story",
+		"Tag GUID":     "",
+		"Numeric GUID": "",
+		"Link wins":    "https://example.com/news/linked",
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(items), len(want))
+	}
+	for _, item := range items {
+		if item.URL != want[item.Title] {
+			t.Errorf("%q: URL = %q, want %q", item.Title, item.URL, want[item.Title])
+		}
+	}
+}
+
+func TestClustersKeepDedupedSources(t *testing.T) {
+	now := time.Now()
+	wire := "Earthquake of magnitude 7.1 strikes off the coast of Japan"
+	items := sortAndDedup([]NewsItem{
+		{Title: wire, Source: "AP", URL: "https://ap.example/quake", Published: now},
+		{Title: wire, Source: "Reuters", URL: "https://reuters.example/quake", Published: now.Add(-time.Minute)},
+		{Title: wire, Source: "Reuters", URL: "https://reuters.example/quake-2", Published: now.Add(-2 * time.Minute)},
+		{Title: "Markets open flat", Source: "BBC", Published: now},
+	}, Options{})
+
+	if len(items) != 2 {
+		t.Fatalf("dedup kept %d items, want 2", len(items))
+	}
+	quake := items[0]
+	if quake.SourceCount != 2 || len(quake.Duplicates) != 1 || quake.Duplicates[0].URL != "https://reuters.example/quake" {
+		t.Fatalf("merged item: SourceCount %d, Duplicates %+v", quake.SourceCount, quake.Duplicates)
+	}
+
+	clusters := Clusters(items)
+	if len(clusters) != 1 {
+		t.Fatalf("got %d clusters, want the syndicated story", len(clusters))
+	}
+	var urls []string
+	for _, item := range clusters[0] {
+		urls = append(urls, item.URL)
+	}
+	if want := "https://ap.example/quake https://reuters.example/quake"; strings.Join(urls, " ") != want {
+		t.Errorf("cluster links = %v, want %s", urls, want)
+	}
+}
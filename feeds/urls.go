@@ -0,0 +1,154 @@
+package feeds
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters that only identify the referrer
+var trackingParams = map[string]bool{
+	"fbclid": true,
+	"gclid":  true,
+	"mc_cid": true,
+	"mc_eid": true,
+	"ocid":   true,
+}
+
+// normalizeURL turns a feed item link into a clean, direct article URL:
+// relative links are resolved against base, Google News redirectors are
+// unwrapped where the target is recoverable, and tracking params dropped.
+// Anything that can't be parsed is returned as-is.
+func normalizeURL(link, base string) string {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return ""
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	if !u.IsAbs() {
+		b, err := url.Parse(base)
+		if err != nil || !b.IsAbs() {
+			return link
+		}
+		u = b.ResolveReference(u)
+	}
+
+	if target, ok := unwrapRedirect(u); ok {
+		u = target
+	}
+
+	stripTracking(u)
+	return u.String()
+}
+
+// guidURL returns the entry GUID when it is an absolute http(s) URL, as
+// some feeds leave the link empty and put the article URL there, and ""
+// for opaque ids such as "tag:..." or numbers.
+func guidURL(guid string) string {
+	guid = strings.TrimSpace(guid)
+	u, err := url.Parse(guid)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return guid
+}
+
+// unwrapRedirect recovers the article URL from Google redirect links:
+//
+//	https://www.google.com/url?q=<target>
+//	https://news.google.com/news/url?url=<target>
+//	https://news.google.com/rss/articles/<base64 id>?oc=5
+//
+// Newer article ids are opaque and only resolvable through Google, so
+// those links are left alone.
+func unwrapRedirect(u *url.URL) (*url.URL, bool) {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if host != "google.com" && host != "news.google.com" {
+		return nil, false
+	}
+
+	q := u.Query()
+	for _, key := range []string{"url", "q"} {
+		if t := q.Get(key); t != "" {
+			if target, err := url.Parse(t); err == nil && target.IsAbs() && strings.HasPrefix(target.Scheme, "http") {
+				return target, true
+			}
+		}
+	}
+
+	if host != "news.google.com" {
+		return nil, false
+	}
+	const marker = "/articles/"
+	i := strings.Index(u.Path, marker)
+	if i < 0 {
+		return nil, false
+	}
+	id := u.Path[i+len(marker):]
+	if t := decodeArticleID(id); t != "" {
+		if target, err := url.Parse(t); err == nil && target.IsAbs() {
+			return target, true
+		}
+	}
+	return nil, false
+}
+
+// decodeArticleID extracts the URL embedded in an old-style Google News
+// article id: base64url-encoded protobuf with the link as a plain string.
+func decodeArticleID(id string) string {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(id, "="))
+	if err != nil {
+		return ""
+	}
+	s := string(raw)
+	start := strings.Index(s, "https://")
+	if start < 0 {
+		start = strings.Index(s, "http://")
+	}
+	if start < 0 {
+		return ""
+	}
+	// The string is length-prefixed (a one- or two-byte varint); trust that
+	// when it fits, else stop at the first non-URL byte.
+	if n := protoLen(s[:start]); n > 0 && start+n <= len(s) {
+		return s[start : start+n]
+	}
+	end := start
+	for end < len(s) && s[end] > ' ' && s[end] < 0x7f {
+		end++
+	}
+	return s[start:end]
+}
+
+// protoLen decodes the varint length that ends right before a string
+func protoLen(prefix string) int {
+	n := len(prefix)
+	switch {
+	case n >= 2 && prefix[n-2] >= 0x80 && prefix[n-1] < 0x80:
+		return int(prefix[n-2]&0x7f) | int(prefix[n-1])<<7
+	case n >= 1 && prefix[n-1] < 0x80:
+		return int(prefix[n-1])
+	}
+	return 0
+}
+
+// stripTracking removes utm_* and other referrer-only query params
+func stripTracking(u *url.URL) {
+	if u.RawQuery == "" {
+		return
+	}
+	q := u.Query()
+	changed := false
+	for key := range q {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || trackingParams[strings.ToLower(key)] {
+			q.Del(key)
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = q.Encode()
+	}
+}
@@ -0,0 +1,101 @@
+package feeds
+
+import "testing"
+
+func TestNormalizeURL(t *testing.T) {
+	const base = "https://news.google.com/rss/search?q=lisbon"
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{
+			"google news old-style id",
+			"https://news.google.com/rss/articles/CBMiM2h0dHBzOi8vd3d3LmJiYy5jby51ay9uZXdzL3dvcmxkLXVzLWNhbmFkYS02NzQ1NjQ5ONIBAA?oc=5",
+			"https://www.bbc.co.uk/news/world-us-canada-67456498",
+		},
+		{
+			// 136-byte URL: two-byte varint length, utm param stripped after unwrapping
+			"google news old-style id, long url",
+			"https://news.google.com/rss/articles/CBMiiAFodHRwczovL3d3dy5yZXV0ZXJzLmNvbS93b3JsZC9ldXJvcGUvdWtyYWluZS1zYXlzLWl0LXNob3QtZG93bi1kcm9uZXMtb3Zlcm5pZ2h0LXJ1c3NpYW4tYXR0YWNrLW9uLWt5aXYtcmVnaW9uLTIwMjQtMDMtMjEvP3V0bV9zb3VyY2U9cnNz0gEA?oc=5",
+			"https://www.reuters.com/world/europe/ukraine-says-it-shot-down-drones-overnight-russian-attack-on-kyiv-region-2024-03-21/",
+		},
+		{
+			// AMP link follows the canonical one; the length prefix stops before it
+			"google news old-style id with amp url",
+			"https://news.google.com/rss/articles/CBMiIWh0dHBzOi8vYXBuZXdzLmNvbS9hcnRpY2xlL2FiYzEyM9IBJWh0dHBzOi8vYXBuZXdzLmNvbS9hcnRpY2xlL2FiYzEyMy9hbXA?oc=5",
+			"https://apnews.com/article/abc123",
+		},
+		{
+			"google news new-style opaque id is kept",
+			"https://news.google.com/rss/articles/CBMiWEFVX3lxTE9wYXF1ZU5ld1N0eWxlSWRlbnRpZmllclRoYXRHb29nbGVSZXNvbHZlc1NlcnZlclNpZGVPbmx5X2FiY2RlZmdoaWprbG1ub3BxcnN0dXZ3eHk?oc=5",
+			"https://news.google.com/rss/articles/CBMiWEFVX3lxTE9wYXF1ZU5ld1N0eWxlSWRlbnRpZmllclRoYXRHb29nbGVSZXNvbHZlc1NlcnZlclNpZGVPbmx5X2FiY2RlZmdoaWprbG1ub3BxcnN0dXZ3eHk?oc=5",
+		},
+		{
+			"google redirector",
+			"https://www.google.com/url?rct=j&sa=t&url=https://www.theguardian.com/world/2024/mar/21/story&ct=ga",
+			"https://www.theguardian.com/world/2024/mar/21/story",
+		},
+		{
+			"google q redirector",
+			"https://www.google.com/url?q=https%3A%2F%2Fwww.aljazeera.com%2Fnews%2F2024%2F3%2F21%2Fstory%3Futm_medium%3Demail&sa=D",
+			"https://www.aljazeera.com/news/2024/3/21/story",
+		},
+		{
+			"relative link",
+			"/articles/local-story?oc=5",
+			"https://news.google.com/articles/local-story?oc=5",
+		},
+		{
+			"tracking params stripped, others kept",
+			"https://example.com/story?id=42&utm_source=rss&utm_campaign=feed&fbclid=abc",
+			"https://example.com/story?id=42",
+		},
+		{"untouched", "https://example.com/story", "https://example.com/story"},
+		{"empty", "  ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeURL(tt.link, base); got != tt.want {
+				t.Errorf("normalizeURL(%q) = %q, want %q", tt.link, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeArticleID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"CBMiM2h0dHBzOi8vd3d3LmJiYy5jby51ay9uZXdzL3dvcmxkLXVzLWNhbmFkYS02NzQ1NjQ5ONIBAA", "https://www.bbc.co.uk/news/world-us-canada-67456498"},
+		{"CBMiWEFVX3lxTE9wYXF1ZU5ld1N0eWxlSWRlbnRpZmllclRoYXRHb29nbGVSZXNvbHZlc1NlcnZlclNpZGVPbmx5X2FiY2RlZmdoaWprbG1ub3BxcnN0dXZ3eHk", ""},
+		{"not base64!", ""},
+	}
+	for _, tt := range tests {
+		if got := decodeArticleID(tt.id); got != tt.want {
+			t.Errorf("decodeArticleID(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestGUIDURL(t *testing.T) {
+	tests := []struct {
+		guid string
+		want string
+	}{
+		{"https://example.com/story/1", "https://example.com/story/1"},
+		{" http://example.com/story/2 ", "http://example.com/story/2"},
+		{"tag:example.com,2024:story-7", ""},
+		{"urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a", ""},
+		{"123456", ""},
+		{"/relative/story", ""},
+		{"https://", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := guidURL(tt.guid); got != tt.want {
+			t.Errorf("guidURL(%q) = %q, want %q", tt.guid, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Package fixtures serves canned JSON in place of live network data, so the
+// dashboard can be developed, demoed and screenshotted offline and without
+// API keys. Set WATCHTOWER_FIXTURES to a directory of <name>.json files
+// (fixtures/sample is a complete set); every fetcher then reads its fixture
+// instead of calling out.
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// EnvVar names the fixture directory; unset or empty means live data
+const EnvVar = "WATCHTOWER_FIXTURES"
+
+// Dir returns the fixture directory, or "" when fixture mode is off
+func Dir() string {
+	return os.Getenv(EnvVar)
+}
+
+// Load decodes <dir>/<name>.json into v. ok is false when fixture mode is
+// off and the caller should fetch live; otherwise err reports a missing or
+// malformed fixture, which the caller surfaces like a fetch error.
+func Load(name string, v interface{}) (ok bool, err error) {
+	dir := Dir()
+	if dir == "" {
+		return false, nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		return true, fmt.Errorf("fixture %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return true, fmt.Errorf("fixture %s: %w", name, err)
+	}
+	return true, nil
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+	"watchtower/paths"
 )
 
 // cachedBrief is the on-disk representation — identical to Brief but
@@ -22,7 +23,7 @@ func cacheFilePath() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	dir := filepath.Join(home, ".cache", "watchtower")
+	dir := paths.CacheDir(home)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return "", err
 	}
@@ -56,19 +57,24 @@ func LoadCachedBrief(maxAge time.Duration) (*Brief, error) {
 		return nil, nil
 	}
 
-	return &Brief{
+	b := &Brief{
 		Summary:      cb.Summary,
 		KeyThreats:   cb.KeyThreats,
 		CountryRisks: cb.CountryRisks,
 		GeneratedAt:  cb.GeneratedAt,
 		Model:        cb.Model,
-	}, nil
+	}
+	// A placeholder written by an older version — treat as missing
+	if b.IsPlaceholder() {
+		return nil, nil
+	}
+	return b, nil
 }
 
 // SaveCachedBrief writes the brief to disk, silently ignoring errors
 // (a cache write failure should never crash the app).
 func SaveCachedBrief(b *Brief) {
-	if b == nil {
+	if b == nil || b.Model == HeuristicModel || b.IsPlaceholder() {
 		return
 	}
 	path, err := cacheFilePath()
@@ -94,17 +100,27 @@ func SaveCachedBrief(b *Brief) {
 	_ = os.Rename(tmp, path)
 }
 
-// ClearBriefCache deletes the cached brief file.
-func ClearBriefCache() error {
+// ClearBriefCache deletes the cached brief file. It returns the path it
+// removed, or "" if there was no cache.
+func ClearBriefCache() (string, error) {
 	path, err := cacheFilePath()
 	if err != nil {
-		return err
+		return "", err
 	}
-	err = os.Remove(path)
+	return removeIfExists(path)
+}
+
+// removeIfExists deletes path, returning it if a file was removed or ""
+// if there was nothing to delete.
+func removeIfExists(path string) (string, error) {
+	err := os.Remove(path)
 	if os.IsNotExist(err) {
-		return nil
+		return "", nil
+	}
+	if err != nil {
+		return "", err
 	}
-	return err
+	return path, nil
 }
 
 // ─── Local Brief Caching ─────────────────────────────────────────────────────
@@ -112,25 +128,42 @@ func ClearBriefCache() error {
 // cachedLocalBrief is the on-disk representation for local brief
 type cachedLocalBrief struct {
 	Summary     string    `json:"summary"`
+	Actions     []string  `json:"actions,omitempty"`
 	GeneratedAt time.Time `json:"generated_at"`
 	Model       string    `json:"model"`
 }
 
-func localCacheFilePath() (string, error) {
+// Cache files of the two local brief modes; each is cached on its own so
+// switching modes doesn't throw the other away
+const (
+	localBriefFile  = "local_brief.json"
+	safetyBriefFile = "safety_brief.json"
+)
+
+func localCacheFilePath(name string) (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	dir := filepath.Join(home, ".cache", "watchtower")
+	dir := paths.CacheDir(home)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return "", err
 	}
-	return filepath.Join(dir, "local_brief.json"), nil
+	return filepath.Join(dir, name), nil
 }
 
 // LoadCachedLocalBrief reads the cached local brief from disk.
 func LoadCachedLocalBrief(maxAge time.Duration) (*LocalBrief, error) {
-	path, err := localCacheFilePath()
+	return loadLocalBriefFile(localBriefFile, maxAge)
+}
+
+// LoadCachedSafetyBrief reads the cached local safety brief from disk.
+func LoadCachedSafetyBrief(maxAge time.Duration) (*LocalBrief, error) {
+	return loadLocalBriefFile(safetyBriefFile, maxAge)
+}
+
+func loadLocalBriefFile(name string, maxAge time.Duration) (*LocalBrief, error) {
+	path, err := localCacheFilePath(name)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +187,7 @@ func LoadCachedLocalBrief(maxAge time.Duration) (*LocalBrief, error) {
 
 	return &LocalBrief{
 		Summary:     cb.Summary,
+		Actions:     cb.Actions,
 		GeneratedAt: cb.GeneratedAt,
 		Model:       cb.Model,
 	}, nil
@@ -161,15 +195,25 @@ func LoadCachedLocalBrief(maxAge time.Duration) (*LocalBrief, error) {
 
 // SaveCachedLocalBrief writes the local brief to disk
 func SaveCachedLocalBrief(b *LocalBrief) {
+	saveLocalBriefFile(localBriefFile, b)
+}
+
+// SaveCachedSafetyBrief writes the local safety brief to disk
+func SaveCachedSafetyBrief(b *LocalBrief) {
+	saveLocalBriefFile(safetyBriefFile, b)
+}
+
+func saveLocalBriefFile(name string, b *LocalBrief) {
 	if b == nil {
 		return
 	}
-	path, err := localCacheFilePath()
+	path, err := localCacheFilePath(name)
 	if err != nil {
 		return
 	}
 	cb := cachedLocalBrief{
 		Summary:     b.Summary,
+		Actions:     b.Actions,
 		GeneratedAt: b.GeneratedAt,
 		Model:       b.Model,
 	}
@@ -184,15 +228,22 @@ func SaveCachedLocalBrief(b *LocalBrief) {
 	_ = os.Rename(tmp, path)
 }
 
-// ClearLocalBriefCache deletes the cached local brief file.
-func ClearLocalBriefCache() error {
-	path, err := localCacheFilePath()
+// ClearLocalBriefCache deletes the cached local brief file. It returns the
+// path it removed, or "" if there was no cache.
+func ClearLocalBriefCache() (string, error) {
+	path, err := localCacheFilePath(localBriefFile)
 	if err != nil {
-		return err
+		return "", err
 	}
-	err = os.Remove(path)
-	if os.IsNotExist(err) {
-		return nil
+	return removeIfExists(path)
+}
+
+// ClearSafetyBriefCache deletes the cached local safety brief file, like
+// ClearLocalBriefCache.
+func ClearSafetyBriefCache() (string, error) {
+	path, err := localCacheFilePath(safetyBriefFile)
+	if err != nil {
+		return "", err
 	}
-	return err
+	return removeIfExists(path)
 }
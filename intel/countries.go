@@ -0,0 +1,161 @@
+package intel
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// countryAliases maps lower-cased variants an LLM tends to produce onto one
+// canonical name, so risk rows stay comparable across briefs.
+var countryAliases = map[string]string{
+	"us":                                    "United States",
+	"u.s.":                                  "United States",
+	"usa":                                   "United States",
+	"u.s.a.":                                "United States",
+	"united states of america":              "United States",
+	"america":                               "United States",
+	"uk":                                    "United Kingdom",
+	"u.k.":                                  "United Kingdom",
+	"britain":                               "United Kingdom",
+	"great britain":                         "United Kingdom",
+	"england":                               "United Kingdom",
+	"russian federation":                    "Russia",
+	"prc":                                   "China",
+	"people's republic of china":            "China",
+	"mainland china":                        "China",
+	"republic of china":                     "Taiwan",
+	"dprk":                                  "North Korea",
+	"democratic people's republic of korea": "North Korea",
+	"republic of korea":                     "South Korea",
+	"rok":                                   "South Korea",
+	"korea":                                 "South Korea",
+	"drc":                                   "DR Congo",
+	"dr congo":                              "DR Congo",
+	"democratic republic of the congo":      "DR Congo",
+	"congo-kinshasa":                        "DR Congo",
+	"gaza":                                  "Palestine",
+	"gaza strip":                            "Palestine",
+	"west bank":                             "Palestine",
+	"palestinian territories":               "Palestine",
+	"state of palestine":                    "Palestine",
+	"islamic republic of iran":              "Iran",
+	"syrian arab republic":                  "Syria",
+	"burma":                                 "Myanmar",
+	"türkiye":                               "Turkey",
+	"turkiye":                               "Turkey",
+	"uae":                                   "United Arab Emirates",
+	"ivory coast":                           "Côte d'Ivoire",
+	"cote d'ivoire":                         "Côte d'Ivoire",
+	"czechia":                               "Czech Republic",
+}
+
+// NormalizeCountry returns the canonical spelling of a country name,
+// trimming whitespace and mapping common aliases ("USA", "Russian
+// Federation") onto one name. Unknown names are returned trimmed.
+func NormalizeCountry(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if canon, ok := countryAliases[strings.ToLower(name)]; ok {
+		return canon
+	}
+	return name
+}
+
+// adjectiveSuffixes turn a country's name into its adjective or people
+// ("Russian", "Sudanese", "Omani", "Iranians"). Only these may follow a
+// name, so "Oman" doesn't match "Omaha".
+var adjectiveSuffixes = []string{"n", "ns", "ese", "i", "is", "ian", "ians"}
+
+// adjectiveClashes lists suffixes that would make another country's
+// adjective out of a name: "Niger" + "ian" is Nigerian.
+var adjectiveClashes = map[string][]string{
+	"niger":    {"ian", "ians"},
+	"dominica": {"n", "ns"},
+}
+
+// CountryMatcher returns a best-effort test for whether a text refers to
+// country: by name, by an alias, or by its adjective, so "Russia" also
+// matches "Russian". Short aliases (US, UK, UAE) only match in capitals as
+// whole words, so "us" the pronoun doesn't count.
+func CountryMatcher(country string) func(text string) bool {
+	re := countryPattern(country)
+	if re == nil {
+		return func(string) bool { return false }
+	}
+	return re.MatchString
+}
+
+// countryPattern matches any mention of country for CountryMatcher; nil
+// for an empty name
+func countryPattern(country string) *regexp.Regexp {
+	country = NormalizeCountry(country)
+	if country == "" {
+		return nil
+	}
+	terms := []string{country}
+	for alias, canon := range countryAliases {
+		if canon == country {
+			terms = append(terms, alias)
+		}
+	}
+	sort.Strings(terms) // stable pattern for equal inputs
+	var parts []string
+	for _, t := range terms {
+		if len(strings.ReplaceAll(t, ".", "")) <= 3 {
+			parts = append(parts, `\b`+regexp.QuoteMeta(strings.ToUpper(t))+`(?:\W|$)`)
+		} else {
+			parts = append(parts, `(?i:\b`+regexp.QuoteMeta(t)+`(?:`+strings.Join(nameSuffixes(t), "|")+`)?\b)`)
+		}
+	}
+	return regexp.MustCompile(strings.Join(parts, "|"))
+}
+
+// nameSuffixes is adjectiveSuffixes less the ones that clash for name
+func nameSuffixes(name string) []string {
+	clash := adjectiveClashes[strings.ToLower(name)]
+	var out []string
+	for _, s := range adjectiveSuffixes {
+		if !slices.Contains(clash, s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// TopCountry returns the country the brief's summary and key threats
+// mention most, or "" if none. Candidates are the countries in its risk
+// index and those countryAliases know; ties go to the higher risk.
+func TopCountry(b *Brief) string {
+	if b == nil {
+		return ""
+	}
+	text := b.Summary + "\n" + strings.Join(b.KeyThreats, "\n")
+	var candidates []string
+	seen := map[string]bool{}
+	add := func(c string) {
+		if c = NormalizeCountry(c); c != "" && !seen[c] {
+			seen[c] = true
+			candidates = append(candidates, c)
+		}
+	}
+	for _, r := range b.CountryRisks {
+		add(r.Country)
+	}
+	var known []string
+	for _, canon := range countryAliases {
+		known = append(known, canon)
+	}
+	sort.Strings(known)
+	for _, c := range known {
+		add(c)
+	}
+
+	best, bestN := "", 0
+	for _, c := range candidates {
+		if n := len(countryPattern(c).FindAllStringIndex(text, -1)); n > bestN {
+			best, bestN = c, n
+		}
+	}
+	return best
+}
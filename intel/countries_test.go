@@ -0,0 +1,32 @@
+package intel
+
+import "testing"
+
+func TestCountryMatcher(t *testing.T) {
+	tests := []struct {
+		country string
+		text    string
+		want    bool
+	}{
+		{"Russia", "Russia launches drones", true},
+		{"Russia", "Russian forces advance", true},
+		{"Russia", "Russians vote", true},
+		{"Sudan", "Sudanese army retakes city", true},
+		{"Oman", "Omani mediators meet", true},
+		{"Oman", "Omaha storm damage", false},
+		{"Iran", "Iranians protest", true},
+		{"Niger", "Niger junta expels envoy", true},
+		{"Niger", "Nigeria's election results delayed", false},
+		{"Niger", "Nigerian troops deploy", false},
+		{"Nigeria", "Nigerian troops deploy", true},
+		{"Dominica", "Dominican Republic election", false},
+		{"United States", "US sanctions announced", true},
+		{"United States", "tell us more", false},
+		{"", "anything", false},
+	}
+	for _, tt := range tests {
+		if got := CountryMatcher(tt.country)(tt.text); got != tt.want {
+			t.Errorf("CountryMatcher(%q)(%q) = %v, want %v", tt.country, tt.text, got, tt.want)
+		}
+	}
+}
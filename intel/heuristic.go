@@ -0,0 +1,71 @@
+package intel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"watchtower/feeds"
+)
+
+// HeuristicModel is the Model name stamped on briefs built without an LLM
+const HeuristicModel = "heuristic (no AI)"
+
+// HeuristicBrief builds a brief locally from the classified headlines so the
+// overview has something useful to show before an API key is configured.
+// KeyThreats holds the top critical/high headlines and the summary is a
+// count breakdown by threat level and category.
+func HeuristicBrief(items []feeds.NewsItem) *Brief {
+	b := &Brief{
+		GeneratedAt: time.Now(),
+		Model:       HeuristicModel,
+	}
+	if len(items) == 0 {
+		b.Summary = noNewsSummary
+		return b
+	}
+
+	levels := map[feeds.ThreatLevel]int{}
+	categories := map[string]int{}
+	for _, item := range items {
+		levels[item.ThreatLevel]++
+		if item.ThreatLevel >= feeds.ThreatMedium {
+			categories[item.Category]++
+		}
+		if item.ThreatLevel >= feeds.ThreatHigh && len(b.KeyThreats) < 5 {
+			b.KeyThreats = append(b.KeyThreats, fmt.Sprintf("%s (%s)", item.Title, item.Source))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d headlines in the last 24h: %d critical, %d high, %d medium.",
+		len(items), levels[feeds.ThreatCritical], levels[feeds.ThreatHigh], levels[feeds.ThreatMedium]))
+
+	type catCount struct {
+		name  string
+		count int
+	}
+	var cats []catCount
+	for name, n := range categories {
+		cats = append(cats, catCount{name, n})
+	}
+	sort.Slice(cats, func(i, j int) bool {
+		if cats[i].count != cats[j].count {
+			return cats[i].count > cats[j].count
+		}
+		return cats[i].name < cats[j].name
+	})
+	if len(cats) > 0 {
+		var parts []string
+		for i, c := range cats {
+			if i >= 3 {
+				break
+			}
+			parts = append(parts, fmt.Sprintf("%s (%d)", c.name, c.count))
+		}
+		sb.WriteString(" Most active: " + strings.Join(parts, ", ") + ".")
+	}
+	b.Summary = sb.String()
+
+	return b
+}
@@ -0,0 +1,170 @@
+package intel
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"watchtower/paths"
+)
+
+// RiskRecord is one row of the country risk time series
+type RiskRecord struct {
+	Time    time.Time
+	Country string
+	Score   int
+}
+
+func riskHistoryFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := paths.CacheDir(home)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "risk_history.csv"), nil
+}
+
+// AppendRiskHistory appends the brief's country risks to the CSV history.
+// Only freshly generated briefs should be recorded, otherwise cache loads
+// would duplicate rows.
+func AppendRiskHistory(b *Brief) error {
+	if b == nil || len(b.CountryRisks) == 0 {
+		return nil
+	}
+	path, err := riskHistoryFilePath()
+	if err != nil {
+		return err
+	}
+
+	_, statErr := os.Stat(path)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening risk history: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if os.IsNotExist(statErr) {
+		w.Write([]string{"timestamp", "country", "score"})
+	}
+	ts := b.GeneratedAt.UTC().Format(time.RFC3339)
+	for _, cr := range b.CountryRisks {
+		w.Write([]string{ts, NormalizeCountry(cr.Country), strconv.Itoa(cr.Score)})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// LoadRiskHistory reads every recorded risk row, oldest first.
+// A missing history file yields no rows and no error.
+func LoadRiskHistory() ([]RiskRecord, error) {
+	path, err := riskHistoryFilePath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 3
+	var records []RiskRecord
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Skip malformed lines rather than losing the whole history
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, row[0])
+		if err != nil {
+			continue // header or corrupt timestamp
+		}
+		score, err := strconv.Atoi(row[2])
+		if err != nil {
+			continue
+		}
+		records = append(records, RiskRecord{Time: t, Country: row[1], Score: score})
+	}
+	return records, nil
+}
+
+// ClearRiskHistory deletes the risk history CSV. It returns the path it
+// removed, or "" if nothing was recorded yet.
+func ClearRiskHistory() (string, error) {
+	path, err := riskHistoryFilePath()
+	if err != nil {
+		return "", err
+	}
+	return removeIfExists(path)
+}
+
+// TrimRiskHistory drops the oldest briefs' rows from the risk history until
+// the file fits in maxBytes, keeping the header and never splitting one
+// brief's rows. It returns how many rows went.
+func TrimRiskHistory(maxBytes int64) (int, error) {
+	path, err := riskHistoryFilePath()
+	if err != nil {
+		return 0, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	size := int64(len(data))
+	if size <= maxBytes {
+		return 0, nil
+	}
+
+	rows := strings.SplitAfter(string(data), "\n")
+	if rows[len(rows)-1] == "" {
+		rows = rows[:len(rows)-1]
+	}
+	header := ""
+	if len(rows) > 0 && strings.HasPrefix(rows[0], "timestamp,") {
+		header, rows = rows[0], rows[1:]
+	}
+	stamp := func(row string) string { return row[:strings.IndexByte(row+",", ',')] }
+	drop := 0
+	for drop < len(rows) && (size > maxBytes || drop > 0 && stamp(rows[drop]) == stamp(rows[drop-1])) {
+		size -= int64(len(rows[drop]))
+		drop++
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(header+strings.Join(rows[drop:], "")), 0644); err != nil {
+		return 0, err
+	}
+	return drop, os.Rename(tmp, path)
+}
+
+// PreviousRisks returns each country's last score recorded before t (the
+// GeneratedAt of the brief being compared), keyed by NormalizeCountry.
+// Rows of that brief itself share its timestamp and are skipped.
+func PreviousRisks(records []RiskRecord, t time.Time) map[string]int {
+	cutoff := t.Truncate(time.Second)
+	prev := make(map[string]int)
+	for _, r := range records {
+		if r.Time.Before(cutoff) {
+			prev[NormalizeCountry(r.Country)] = r.Score
+		}
+	}
+	return prev
+}
@@ -0,0 +1,51 @@
+package intel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTrimRiskHistory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		b := &Brief{GeneratedAt: base.Add(time.Duration(i) * time.Hour), CountryRisks: []CountryRisk{
+			{Country: "Ukraine", Score: 80 + i},
+			{Country: "Sudan", Score: 70 + i},
+		}}
+		if err := AppendRiskHistory(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path, _ := riskHistoryFilePath()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One byte short: the oldest brief has to go, both of its rows
+	removed, err := TrimRiskHistory(info.Size() - 1)
+	if err != nil || removed != 2 {
+		t.Fatalf("TrimRiskHistory = %d, %v; want 2 rows removed", removed, err)
+	}
+	records, err := LoadRiskHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 || !records[0].Time.Equal(base.Add(time.Hour)) {
+		t.Errorf("after trim: %+v", records)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data[:len("timestamp,")]) != "timestamp," {
+		t.Errorf("header lost: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "risk_history.csv.tmp")); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind")
+	}
+
+	if removed, err := TrimRiskHistory(1 << 20); err != nil || removed != 0 {
+		t.Errorf("under the limit: TrimRiskHistory = %d, %v; want nothing removed", removed, err)
+	}
+}
@@ -1,34 +1,39 @@
 package intel
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 	"watchtower/feeds"
+	"watchtower/fixtures"
 	"watchtower/weather"
 )
 
 type Provider string
 
 const (
-	ProviderGroq     Provider = "groq"
-	ProviderOpenAI   Provider = "openai"
-	ProviderDeepSeek Provider = "deepseek"
-	ProviderGemini   Provider = "gemini"
-	ProviderClaude   Provider = "claude"
-	ProviderLocal    Provider = "local"
+	ProviderGroq       Provider = "groq"
+	ProviderOpenAI     Provider = "openai"
+	ProviderDeepSeek   Provider = "deepseek"
+	ProviderGemini     Provider = "gemini"
+	ProviderClaude     Provider = "claude"
+	ProviderLocal      Provider = "local"
+	ProviderOpenRouter Provider = "openrouter"
+	ProviderAzure      Provider = "azure"
 )
 
+// providerDefaults is the single source of truth for each provider: where
+// to send requests, how to authenticate and which wire format it speaks.
 var providerDefaults = map[Provider]struct {
 	endpoint     string
 	defaultModel string
 	authHeader   string
 	authPrefix   string
+	adapter      adapter // nil = OpenAI-compatible
 }{
 	ProviderGroq: {
 		endpoint:     "https://api.groq.com/openai/v1/chat/completions",
@@ -53,12 +58,14 @@ var providerDefaults = map[Provider]struct {
 		defaultModel: "gemini-1.5-flash",
 		authHeader:   "X-Goog-Api-Key",
 		authPrefix:   "",
+		adapter:      geminiAdapter{},
 	},
 	ProviderClaude: {
 		endpoint:     "https://api.anthropic.com/v1/messages",
 		defaultModel: "claude-3-haiku-20240307",
 		authHeader:   "x-api-key",
 		authPrefix:   "",
+		adapter:      claudeAdapter{},
 	},
 	ProviderLocal: {
 		endpoint:     "http://localhost:11434/v1/chat/completions",
@@ -66,37 +73,101 @@ var providerDefaults = map[Provider]struct {
 		authHeader:   "Authorization",
 		authPrefix:   "Bearer ",
 	},
+	ProviderOpenRouter: {
+		endpoint:     "https://openrouter.ai/api/v1/chat/completions",
+		defaultModel: "openai/gpt-4o-mini",
+		authHeader:   "Authorization",
+		authPrefix:   "Bearer ",
+		// Attribution headers OpenRouter recommends for apps
+		adapter: openAIAdapter{headers: map[string]string{
+			"HTTP-Referer": "https://github.com/lajosdeme/watchtower",
+			"X-Title":      "Watchtower",
+		}},
+	},
+	// The endpoint is per resource and deployment; see LLMConfig.Endpoint.
+	// Requests name the deployment in the URL, so the model field is ignored.
+	ProviderAzure: {
+		authHeader: "api-key",
+		authPrefix: "",
+	},
 }
 
+// DefaultAzureAPIVersion is the Azure OpenAI api-version used when none is configured
+const DefaultAzureAPIVersion = "2024-06-01"
+
 type LLMConfig struct {
-	Provider Provider
-	APIKey   string
-	Model    string
+	Provider  Provider
+	APIKey    string
+	Model     string
+	Gateway   Gateway
+	Azure     Azure       // ProviderAzure only
+	Fallbacks []LLMConfig // tried in order when the provider above fails
+}
+
+// Azure locates an Azure OpenAI deployment
+type Azure struct {
+	Resource   string // resource endpoint, e.g. https://myres.openai.azure.com
+	Deployment string
+	APIVersion string // "" = DefaultAzureAPIVersion
+}
+
+// endpoint returns the chat completions URL of the deployment
+func (a Azure) endpoint() string {
+	version := a.APIVersion
+	if version == "" {
+		version = DefaultAzureAPIVersion
+	}
+	return strings.TrimRight(a.Resource, "/") + "/openai/deployments/" + url.PathEscape(a.Deployment) +
+		"/chat/completions?api-version=" + url.QueryEscape(version)
+}
+
+// Gateway overrides how requests reach a provider, for corporate gateways
+// and proxies. The zero value keeps the providerDefaults entry as is.
+type Gateway struct {
+	Endpoint   string            // replaces the provider's URL
+	AuthHeader string            // replaces the auth header name
+	AuthPrefix string            // goes before the key; only used with AuthHeader
+	Headers    map[string]string // sent with every request
 }
 
 func (c LLMConfig) Endpoint() string {
-	p := providerDefaults[c.Provider]
+	endpoint := providerDefaults[c.Provider].endpoint
+	if c.Provider == ProviderAzure {
+		endpoint = c.Azure.endpoint()
+	}
+	if c.Gateway.Endpoint != "" {
+		endpoint = c.Gateway.Endpoint
+	}
 	if c.Model == "" {
-		return p.endpoint
+		return endpoint
 	}
 	if c.Provider == ProviderGemini {
-		return p.endpoint + "/" + c.Model + ":generateContent"
+		return endpoint + "/" + c.Model + ":generateContent"
 	}
-	return p.endpoint
+	return endpoint
 }
 
 func (c LLMConfig) ModelName() string {
 	if c.Model != "" {
 		return c.Model
 	}
+	if c.Provider == ProviderAzure {
+		return c.Azure.Deployment
+	}
 	return providerDefaults[c.Provider].defaultModel
 }
 
 func (c LLMConfig) AuthHeader() string {
+	if c.Gateway.AuthHeader != "" {
+		return c.Gateway.AuthHeader
+	}
 	return providerDefaults[c.Provider].authHeader
 }
 
 func (c LLMConfig) AuthValue() string {
+	if c.Gateway.AuthHeader != "" {
+		return c.Gateway.AuthPrefix + c.APIKey
+	}
 	p := providerDefaults[c.Provider]
 	return p.authPrefix + c.APIKey
 }
@@ -117,122 +188,238 @@ type Brief struct {
 	Model        string
 }
 
+// noNewsSummary is the placeholder summary used when there was nothing to brief on
+const noNewsSummary = "No news items available to summarize."
+
+// IsPlaceholder reports whether the brief carries no real analysis, e.g.
+// because it was generated from an empty headline list. Placeholders are
+// never cached so a transient feed outage can't suppress real briefs.
+func (b *Brief) IsPlaceholder() bool {
+	return strings.TrimSpace(b.Summary) == "" || b.Summary == noNewsSummary
+}
+
+// WithSections returns a copy of b with the sections disabled in opts
+// removed, e.g. for briefs cached before a section was switched off.
+func (b *Brief) WithSections(opts BriefOptions) *Brief {
+	if b == nil {
+		return nil
+	}
+	c := *b
+	if opts.SkipThreats {
+		c.KeyThreats = nil
+	}
+	if opts.SkipCountryRisks {
+		c.CountryRisks = nil
+	}
+	return &c
+}
+
 // LocalBrief holds an AI-generated summary of local news and weather
 type LocalBrief struct {
 	Summary     string
+	Actions     []string // safety brief only: short practical to-dos for today
 	GeneratedAt time.Time
 	Model       string
 }
 
 var httpClient = &http.Client{Timeout: 30 * time.Second}
 
+// BriefOptions selects the optional brief sections and how much input the
+// prompt gets. The zero value asks for everything from the default number
+// of headlines; the summary is always included.
+type BriefOptions struct {
+	SkipThreats      bool
+	SkipCountryRisks bool
+	Headlines        int    // headlines fed to the prompt; 0 = DefaultBriefHeadlines
+	Language         string // language to write the brief in, e.g. "French"; "" = English
+
+	// Weather, when set, adds a one-line summary of the user's local
+	// conditions so the model can relate regional disasters to the news.
+	Weather *weather.Conditions
+}
+
+const (
+	DefaultBriefHeadlines = 40
+	// MaxBriefHeadlines keeps the prompt well inside small context windows
+	MaxBriefHeadlines = 100
+)
+
+// languageRule asks for the brief's text in o.Language while the section
+// headers, which parseBriefResponse matches literally, and the country
+// names, which are matched against headlines and the risk history, stay
+// English. It is "" for English.
+func (o BriefOptions) languageRule() string {
+	lang := strings.TrimSpace(o.Language)
+	if lang == "" || strings.EqualFold(lang, "english") || strings.EqualFold(lang, "en") {
+		return ""
+	}
+	return fmt.Sprintf("- Respond in %s: write the summary, threats and reasons in %s, but keep the section headers (SUMMARY:, THREATS:, COUNTRY_RISKS:) and country names in English exactly as shown\n", lang, lang)
+}
+
+// headlineLimit clamps the configured headline count to what's available
+func (o BriefOptions) headlineLimit(available int) int {
+	n := o.Headlines
+	if n <= 0 {
+		n = DefaultBriefHeadlines
+	}
+	if n > MaxBriefHeadlines {
+		n = MaxBriefHeadlines
+	}
+	if available < n {
+		n = available
+	}
+	return n
+}
+
+// fixtureBrief serves the brief fixture when fixture mode is on
+func fixtureBrief() (*Brief, bool, error) {
+	var canned Brief
+	ok, err := fixtures.Load("brief", &canned)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	canned.GeneratedAt = time.Now()
+	return &canned, true, nil
+}
+
 // GenerateBrief calls the configured LLM to synthesize a brief, summary, and country risk scores
-func GenerateBrief(ctx context.Context, cfg LLMConfig, items []feeds.NewsItem) (*Brief, error) {
+func GenerateBrief(ctx context.Context, cfg LLMConfig, items []feeds.NewsItem, opts BriefOptions) (*Brief, error) {
+	if b, ok, err := fixtureBrief(); ok {
+		return b.WithSections(opts), err
+	}
 	if cfg.APIKey == "" {
-		return &Brief{
-			Summary:     "No LLM_API_KEY set. Add it to ~/.config/watchtower/config.yaml to enable AI briefings.",
-			GeneratedAt: time.Now(),
-			Model:       "none",
-		}, nil
+		return HeuristicBrief(items).WithSections(opts), nil
 	}
 
 	if len(items) == 0 {
 		return &Brief{
-			Summary:     "No news items available to summarize.",
+			Summary:     noNewsSummary,
 			GeneratedAt: time.Now(),
 		}, nil
 	}
 
-	// Build headline list (top 40 by severity)
-	limit := 40
-	if len(items) < limit {
-		limit = len(items)
-	}
+	prompt := BuildBriefPrompt(items, opts)
+
+	return generateBrief(ctx, cfg, prompt)
+}
+
+// BuildBriefPrompt assembles the exact prompt GenerateBrief sends to the LLM.
+// Disabled sections are left out of both the format and the rules so no
+// tokens are spent on them.
+func BuildBriefPrompt(items []feeds.NewsItem, opts BriefOptions) string {
+	// Build headline list (top N by severity)
+	limit := opts.headlineLimit(len(items))
 	var sb strings.Builder
 	for i, item := range items[:limit] {
 		sb.WriteString(fmt.Sprintf("%d. [%s] %s (%s)\n",
 			i+1, item.ThreatLevel.String(), item.Title, item.Source))
 	}
 
-	prompt := fmt.Sprintf(`You are a geopolitical intelligence analyst. Analyze these recent headlines and respond in EXACTLY this format with no extra text:
-
-SUMMARY:
+	format := `SUMMARY:
 <3-4 sentences covering the most critical global developments right now>
-
+`
+	rules := "- SUMMARY: factual, analyst-toned, no fluff, max 3 sentences\n"
+	if !opts.SkipThreats {
+		format += `
 THREATS:
 • <threat 1, one line>
 • <threat 2, one line>
 • <threat 3, one line>
 • <threat 4, one line>
 • <threat 5, one line>
-
+`
+		rules += "- THREATS: exactly 5 bullets, one line each, most severe first\n"
+	}
+	if !opts.SkipCountryRisks {
+		format += `
 COUNTRY_RISKS:
-<CountryName>|<score 0-100>|<one short reason phrase>
-<CountryName>|<score 0-100>|<one short reason phrase>
-<CountryName>|<score 0-100>|<one short reason phrase>
-<CountryName>|<score 0-100>|<one short reason phrase>
-<CountryName>|<score 0-100>|<one short reason phrase>
-<CountryName>|<score 0-100>|<one short reason phrase>
-<CountryName>|<score 0-100>|<one short reason phrase>
-<CountryName>|<score 0-100>|<one short reason phrase>
+` + strings.Repeat("<CountryName>|<score 0-100>|<one short reason phrase>\n", 8)
+		rules += "- COUNTRY_RISKS: exactly 8 countries most prominent in the news, score reflects current instability/risk (100=active war, 0=stable), pipe-separated, short reason (3-5 words max)\n"
+	}
+
+	rules += opts.languageRule()
+
+	if c := opts.Weather; c != nil {
+		rules += "- Mention the user's local weather only if it relates to a disaster or crisis in the headlines\n"
+		sb.WriteString(fmt.Sprintf("\nUSER'S LOCAL WEATHER:\n%s: %.0f°C, %s, wind %.0f km/h\n",
+			c.City, c.TempC, c.Description, c.WindSpeedKmh))
+	}
 
+	return fmt.Sprintf(`You are a geopolitical intelligence analyst. Analyze these recent headlines and respond in EXACTLY this format with no extra text:
+
+%s
 Rules:
-- SUMMARY: factual, analyst-toned, no fluff, max 3 sentences
-- THREATS: exactly 5 bullets, one line each, most severe first
-- COUNTRY_RISKS: exactly 8 countries most prominent in the news, score reflects current instability/risk (100=active war, 0=stable), pipe-separated, short reason (3-5 words max)
-- No markdown, no extra formatting, no preamble
+%s- No markdown, no extra formatting, no preamble
 
 HEADLINES:
-%s`, sb.String())
-
-	if cfg.Provider == ProviderClaude {
-		return generateClaudeBrief(ctx, cfg, prompt)
-	}
-	if cfg.Provider == ProviderGemini {
-		return generateGeminiBrief(ctx, cfg, prompt)
-	}
-	return generateOpenAICompatibleBrief(ctx, cfg, prompt)
+%s`, format, rules, sb.String())
 }
 
-// GenerateLocalBrief calls the configured LLM to synthesize a local news and weather summary
-func GenerateLocalBrief(ctx context.Context, cfg LLMConfig, city string, items []feeds.NewsItem, cond *weather.Conditions, forecast []weather.DayForecast) (*LocalBrief, error) {
+// GenerateCountryRisks re-requests only the country risk index with a
+// focused prompt, for when the rest of a brief is fine but the scores came
+// back sparse or malformed.
+func GenerateCountryRisks(ctx context.Context, cfg LLMConfig, items []feeds.NewsItem, opts BriefOptions) ([]CountryRisk, error) {
+	if b, ok, err := fixtureBrief(); ok {
+		if err != nil {
+			return nil, err
+		}
+		return b.CountryRisks, nil
+	}
 	if cfg.APIKey == "" {
-		return &LocalBrief{
-			Summary:     "No LLM_API_KEY set. Add it to ~/.config/watchtower/config.yaml to enable AI briefings.",
-			GeneratedAt: time.Now(),
-			Model:       "none",
-		}, nil
+		return nil, fmt.Errorf("country risk scores need llm_api_key")
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("news not loaded yet")
 	}
 
-	var sb strings.Builder
+	prompt := buildCountryRisksPrompt(items, opts)
 
-	// Build local news headline list (top 20)
-	sb.WriteString("LOCAL NEWS HEADLINES:\n")
-	limit := 20
-	if len(items) > limit {
-		items = items[:limit]
+	// The brief generator parses every section, so reuse it and keep
+	// only the risks.
+	b, err := generateBrief(ctx, cfg, prompt)
+	if err != nil {
+		return nil, err
 	}
-	for i, item := range items {
-		sb.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, item.Title, item.Source))
+	if len(b.CountryRisks) == 0 {
+		return nil, fmt.Errorf("%s returned no parseable country risks", cfg.Provider)
 	}
+	return b.CountryRisks, nil
+}
 
-	// Add current weather
-	sb.WriteString("\nCURRENT WEATHER:\n")
-	if cond != nil {
-		sb.WriteString(fmt.Sprintf("Location: %s\n", cond.City))
-		sb.WriteString(fmt.Sprintf("Temperature: %.1f°C (feels like %.1f°C)\n", cond.TempC, cond.FeelsLikeC))
-		sb.WriteString(fmt.Sprintf("Conditions: %s %s\n", cond.Icon, cond.Description))
-		sb.WriteString(fmt.Sprintf("Humidity: %d%%, Wind: %.0f km/h, UV: %.0f\n", cond.Humidity, cond.WindSpeedKmh, cond.UVIndex))
+func buildCountryRisksPrompt(items []feeds.NewsItem, opts BriefOptions) string {
+	limit := opts.headlineLimit(len(items))
+	var sb strings.Builder
+	for i, item := range items[:limit] {
+		sb.WriteString(fmt.Sprintf("%d. [%s] %s (%s)\n",
+			i+1, item.ThreatLevel.String(), item.Title, item.Source))
 	}
 
-	// Add forecast
-	sb.WriteString("\nFORECAST (next 5 days):\n")
-	for i, f := range forecast {
-		if i >= 5 {
-			break
+	return fmt.Sprintf(`You are a geopolitical intelligence analyst. From these recent headlines, score the countries most prominent in the news. Respond in EXACTLY this format with no extra text:
+
+COUNTRY_RISKS:
+%s
+Rules:
+- exactly 8 lines, one country each, most at-risk first
+- score reflects current instability/risk (100=active war, 0=stable)
+- pipe-separated, short reason (3-5 words max)
+%s- No markdown, no extra formatting, no preamble
+
+HEADLINES:
+%s`, strings.Repeat("<CountryName>|<score 0-100>|<one short reason phrase>\n", 8), opts.languageRule(), sb.String())
+}
+
+// GenerateLocalBrief calls the configured LLM to synthesize a local news and weather summary
+func GenerateLocalBrief(ctx context.Context, cfg LLMConfig, city string, items []feeds.NewsItem, cond *weather.Conditions, forecast []weather.DayForecast) (*LocalBrief, error) {
+	var canned LocalBrief
+	if ok, err := fixtures.Load("local_brief", &canned); ok {
+		if err != nil {
+			return nil, err
 		}
-		sb.WriteString(fmt.Sprintf("- %s: %s %s, High: %.0f°C, Low: %.0f°C, Rain: %.1fmm\n",
-			f.Date.Format("Mon Jan 02"), f.Icon, f.Desc, f.MaxTempC, f.MinTempC, f.RainMM))
+		canned.GeneratedAt = time.Now()
+		return &canned, nil
+	}
+	if cfg.APIKey == "" {
+		return noKeyLocalBrief(), nil
 	}
 
 	prompt := fmt.Sprintf(`You are a local news and weather analyst. Summarize this information for %s in 2-3 sentences.
@@ -253,372 +440,141 @@ Rules:
 - Never send back the 'DATA' as is, always explain
 
 DATA:
-%s`, city, sb.String())
+%s`, city, localBriefData(items, cond, forecast))
 
-	if cfg.Provider == ProviderClaude {
-		return generateClaudeLocalBrief(ctx, cfg, prompt)
-	}
-	if cfg.Provider == ProviderGemini {
-		return generateGeminiLocalBrief(ctx, cfg, prompt)
-	}
-	return generateOpenAICompatibleLocalBrief(ctx, cfg, prompt)
+	return generateLocalBrief(ctx, cfg, prompt)
 }
 
-func generateOpenAICompatibleBrief(ctx context.Context, cfg LLMConfig, prompt string) (*Brief, error) {
-	body := map[string]interface{}{
-		"model":       cfg.ModelName(),
-		"temperature": 0,
-		"max_tokens":  700,
-		"messages": []map[string]string{
-			{"role": "user", "content": prompt},
-		},
-	}
-
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", cfg.Endpoint(), bytes.NewReader(bodyBytes))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(cfg.AuthHeader(), cfg.AuthValue())
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("%s request failed: %w", cfg.Provider, err)
+// GenerateSafetyBrief is the local brief's practical variant: from the same
+// news and weather it assesses today's safety and logistics (commute,
+// weather hazards, unrest) and returns a short list of things to do.
+func GenerateSafetyBrief(ctx context.Context, cfg LLMConfig, city string, items []feeds.NewsItem, cond *weather.Conditions, forecast []weather.DayForecast) (*LocalBrief, error) {
+	var canned LocalBrief
+	if ok, err := fixtures.Load("safety_brief", &canned); ok {
+		if err != nil {
+			return nil, err
+		}
+		canned.GeneratedAt = time.Now()
+		return &canned, nil
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("%s HTTP %d", cfg.Provider, resp.StatusCode)
+	if cfg.APIKey == "" {
+		return noKeyLocalBrief(), nil
 	}
 
-	var result struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
-		} `json:"choices"`
-		Model string `json:"model"`
-	}
+	prompt := fmt.Sprintf(`Assess practical safety and logistics in %s for today using the local news and weather below.
+Consider:
+1. Commute and travel disruption (strikes, closures, accidents, transit outages)
+2. Weather hazards (storms, heat, flooding, ice, poor air)
+3. Local unrest, crime or emergencies that affect where people can go
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decoding %s response: %w", cfg.Provider, err)
-	}
-	if len(result.Choices) == 0 {
-		return nil, fmt.Errorf("no response from %s", cfg.Provider)
-	}
+Respond in this exact format with no extra text:
 
-	summary, threats, risks := parseBriefResponse(result.Choices[0].Message.Content)
+SUMMARY:
+<one sentence: overall, is today normal or should people take care?>
 
-	return &Brief{
-		Summary:      summary,
-		KeyThreats:   threats,
-		CountryRisks: risks,
-		GeneratedAt:  time.Now(),
-		Model:        result.Model,
-	}, nil
-}
+ACTIONS:
+- <short actionable item>
+- <short actionable item>
 
-func generateOpenAICompatibleLocalBrief(ctx context.Context, cfg LLMConfig, prompt string) (*LocalBrief, error) {
-	body := map[string]interface{}{
-		"model":       cfg.ModelName(),
-		"temperature": 0,
-		"max_tokens":  300,
-		"messages": []map[string]string{
-			{"role": "user", "content": prompt},
-		},
-	}
+Rules:
+- 2 to 5 actions, each under 15 words, most urgent first
+- Only actions supported by the data; if nothing stands out, say so in one action
+- No markdown formatting beyond the dashes
 
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
+DATA:
+%s`, city, localBriefData(items, cond, forecast))
 
-	req, err := http.NewRequestWithContext(ctx, "POST", cfg.Endpoint(), bytes.NewReader(bodyBytes))
+	text, model, err := cfg.summarizer().Complete(ctx, localBriefSystem, prompt, 300)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set(cfg.AuthHeader(), cfg.AuthValue())
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("%s request failed: %w", cfg.Provider, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("%s HTTP %d", cfg.Provider, resp.StatusCode)
-	}
-
-	var result struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
-		} `json:"choices"`
-		Model string `json:"model"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decoding %s response: %w", cfg.Provider, err)
-	}
-	if len(result.Choices) == 0 {
-		return nil, fmt.Errorf("no response from %s", cfg.Provider)
-	}
-
-	summary := parseLocalBriefResponse(result.Choices[0].Message.Content)
-
+	summary, actions := parseSafetyBriefResponse(text)
 	return &LocalBrief{
 		Summary:     summary,
+		Actions:     actions,
 		GeneratedAt: time.Now(),
-		Model:       result.Model,
+		Model:       model,
 	}, nil
 }
 
-func generateClaudeBrief(ctx context.Context, cfg LLMConfig, prompt string) (*Brief, error) {
-	body := map[string]interface{}{
-		"model":       cfg.ModelName(),
-		"max_tokens":  700,
-		"temperature": 0,
-		"system":      "You are a geopolitical intelligence analyst.",
-		"messages": []map[string]string{
-			{"role": "user", "content": prompt},
-		},
-	}
-
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", cfg.Endpoint(), bytes.NewReader(bodyBytes))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(cfg.AuthHeader(), cfg.AuthValue())
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("anthropic-version", "2023-06-01")
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("claude request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("claude HTTP %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Content []struct {
-			Text string `json:"text"`
-		} `json:"content"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decoding claude response: %w", err)
-	}
-	if len(result.Content) == 0 {
-		return nil, fmt.Errorf("no response from claude")
+// noKeyLocalBrief is shown in place of a local brief when no key is set
+func noKeyLocalBrief() *LocalBrief {
+	return &LocalBrief{
+		Summary:     "No LLM_API_KEY set. Add it to ~/.config/watchtower/config.yaml to enable AI briefings.",
+		GeneratedAt: time.Now(),
+		Model:       "none",
 	}
-
-	summary, threats, risks := parseBriefResponse(result.Content[0].Text)
-
-	return &Brief{
-		Summary:      summary,
-		KeyThreats:   threats,
-		CountryRisks: risks,
-		GeneratedAt:  time.Now(),
-		Model:        cfg.ModelName(),
-	}, nil
 }
 
-func generateClaudeLocalBrief(ctx context.Context, cfg LLMConfig, prompt string) (*LocalBrief, error) {
-	body := map[string]interface{}{
-		"model":       cfg.ModelName(),
-		"max_tokens":  300,
-		"temperature": 0,
-		"system":      "You are a local news and weather analyst.",
-		"messages": []map[string]string{
-			{"role": "user", "content": prompt},
-		},
-	}
-
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", cfg.Endpoint(), bytes.NewReader(bodyBytes))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(cfg.AuthHeader(), cfg.AuthValue())
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("anthropic-version", "2023-06-01")
+// localBriefData formats the local headlines, current weather and forecast
+// for the DATA section of the local brief prompts
+func localBriefData(items []feeds.NewsItem, cond *weather.Conditions, forecast []weather.DayForecast) string {
+	var sb strings.Builder
 
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("claude request failed: %w", err)
+	// Build local news headline list (top 20)
+	sb.WriteString("LOCAL NEWS HEADLINES:\n")
+	limit := 20
+	if len(items) > limit {
+		items = items[:limit]
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("claude HTTP %d", resp.StatusCode)
+	for i, item := range items {
+		sb.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, item.Title, item.Source))
 	}
 
-	var result struct {
-		Content []struct {
-			Text string `json:"text"`
-		} `json:"content"`
+	// Add current weather
+	sb.WriteString("\nCURRENT WEATHER:\n")
+	if cond != nil {
+		sb.WriteString(fmt.Sprintf("Location: %s\n", cond.City))
+		sb.WriteString(fmt.Sprintf("Temperature: %.1f°C (feels like %.1f°C)\n", cond.TempC, cond.FeelsLikeC))
+		sb.WriteString(fmt.Sprintf("Conditions: %s %s\n", cond.Icon, cond.Description))
+		sb.WriteString(fmt.Sprintf("Humidity: %d%%, Wind: %.0f km/h, UV: %.0f\n", cond.Humidity, cond.WindSpeedKmh, cond.UVIndex))
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decoding claude response: %w", err)
-	}
-	if len(result.Content) == 0 {
-		return nil, fmt.Errorf("no response from claude")
+	// Add forecast
+	sb.WriteString("\nFORECAST (next 5 days):\n")
+	for i, f := range forecast {
+		if i >= 5 {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("- %s: %s %s, High: %.0f°C, Low: %.0f°C, Rain: %.1fmm\n",
+			f.Date.Format("Mon Jan 02"), f.Icon, f.Desc, f.MaxTempC, f.MinTempC, f.RainMM))
 	}
-
-	summary := parseLocalBriefResponse(result.Content[0].Text)
-
-	return &LocalBrief{
-		Summary:     summary,
-		GeneratedAt: time.Now(),
-		Model:       cfg.ModelName(),
-	}, nil
+	return sb.String()
 }
 
-func generateGeminiBrief(ctx context.Context, cfg LLMConfig, prompt string) (*Brief, error) {
-	body := map[string]interface{}{
-		"contents": []map[string]interface{}{
-			{
-				"parts": []map[string]string{
-					{"text": prompt},
-				},
-			},
-		},
-		"generationConfig": map[string]interface{}{
-			"temperature":     0,
-			"maxOutputTokens": 700,
-		},
-	}
-
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
+// System prompts for adapters whose API takes the role separately
+const (
+	briefSystem      = "You are a geopolitical intelligence analyst."
+	localBriefSystem = "You are a local news and weather analyst."
+)
 
-	req, err := http.NewRequestWithContext(ctx, "POST", cfg.Endpoint(), bytes.NewReader(bodyBytes))
+// generateBrief sends a brief (or country risk) prompt and parses every
+// section of the answer.
+func generateBrief(ctx context.Context, cfg LLMConfig, prompt string) (*Brief, error) {
+	text, model, err := cfg.summarizer().Complete(ctx, briefSystem, prompt, 700)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set(cfg.AuthHeader(), cfg.AuthValue())
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("gemini request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("gemini HTTP %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Candidates []struct {
-			Content struct {
-				Parts []struct {
-					Text string `json:"text"`
-				} `json:"parts"`
-			} `json:"content"`
-		} `json:"candidates"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decoding gemini response: %w", err)
-	}
-	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
-		return nil, fmt.Errorf("no response from gemini")
-	}
-
-	summary, threats, risks := parseBriefResponse(result.Candidates[0].Content.Parts[0].Text)
-
+	summary, threats, risks := parseBriefResponse(text)
 	return &Brief{
 		Summary:      summary,
 		KeyThreats:   threats,
 		CountryRisks: risks,
 		GeneratedAt:  time.Now(),
-		Model:        cfg.ModelName(),
+		Model:        model,
 	}, nil
 }
 
-func generateGeminiLocalBrief(ctx context.Context, cfg LLMConfig, prompt string) (*LocalBrief, error) {
-	body := map[string]interface{}{
-		"contents": []map[string]interface{}{
-			{
-				"parts": []map[string]string{
-					{"text": prompt},
-				},
-			},
-		},
-		"generationConfig": map[string]interface{}{
-			"temperature":     0,
-			"maxOutputTokens": 300,
-		},
-	}
-
-	bodyBytes, err := json.Marshal(body)
+// generateLocalBrief sends a local brief prompt and parses the summary
+func generateLocalBrief(ctx context.Context, cfg LLMConfig, prompt string) (*LocalBrief, error) {
+	text, model, err := cfg.summarizer().Complete(ctx, localBriefSystem, prompt, 300)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", cfg.Endpoint(), bytes.NewReader(bodyBytes))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(cfg.AuthHeader(), cfg.AuthValue())
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("gemini request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("gemini HTTP %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Candidates []struct {
-			Content struct {
-				Parts []struct {
-					Text string `json:"text"`
-				} `json:"parts"`
-			} `json:"content"`
-		} `json:"candidates"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decoding gemini response: %w", err)
-	}
-	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
-		return nil, fmt.Errorf("no response from gemini")
-	}
-
-	summary := parseLocalBriefResponse(result.Candidates[0].Content.Parts[0].Text)
-
 	return &LocalBrief{
-		Summary:     summary,
+		Summary:     parseLocalBriefResponse(text),
 		GeneratedAt: time.Now(),
-		Model:       cfg.ModelName(),
+		Model:       model,
 	}, nil
 }
 
@@ -644,6 +600,41 @@ func parseLocalBriefResponse(content string) string {
 	return strings.TrimSpace(content)
 }
 
+// parseSafetyBriefResponse splits a safety brief answer into its summary
+// sentence and action list. An answer without the markers becomes the
+// summary as a whole.
+func parseSafetyBriefResponse(content string) (string, []string) {
+	var summary []string
+	var actions []string
+	section := ""
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "SUMMARY:"):
+			section = "summary"
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "SUMMARY:"))
+		case strings.HasPrefix(trimmed, "ACTIONS:"):
+			section = "actions"
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+		switch section {
+		case "summary":
+			summary = append(summary, trimmed)
+		case "actions":
+			if a := strings.TrimSpace(strings.TrimLeft(trimmed, "-•*")); a != "" {
+				actions = append(actions, a)
+			}
+		}
+	}
+	if len(summary) == 0 && len(actions) == 0 {
+		return strings.TrimSpace(content), nil
+	}
+	return strings.Join(summary, " "), actions
+}
+
 func parseBriefResponse(content string) (string, []string, []CountryRisk) {
 	var summary string
 	var threats []string
@@ -710,7 +701,7 @@ func parseBriefResponse(content string) (string, []string, []CountryRisk) {
 		if len(parts) < 2 {
 			continue
 		}
-		country := strings.TrimSpace(parts[0])
+		country := NormalizeCountry(parts[0])
 		score, err := strconv.Atoi(strings.TrimSpace(parts[1]))
 		if err != nil || country == "" {
 			continue
@@ -726,7 +717,35 @@ func parseBriefResponse(content string) (string, []string, []CountryRisk) {
 		})
 	}
 
-	return summary, threats, risks
+	return summary, threats, collapseCountryRisks(risks)
+}
+
+// collapseCountryRisks merges entries the model returned more than once for
+// the same (normalized) country, keeping the highest score and joining the
+// distinct reasons. Order of first appearance is preserved.
+func collapseCountryRisks(risks []CountryRisk) []CountryRisk {
+	idx := make(map[string]int, len(risks))
+	var out []CountryRisk
+	for _, cr := range risks {
+		key := strings.ToLower(cr.Country)
+		i, seen := idx[key]
+		if !seen {
+			idx[key] = len(out)
+			out = append(out, cr)
+			continue
+		}
+		if cr.Score > out[i].Score {
+			out[i].Score = cr.Score
+		}
+		if cr.Reason != "" && !strings.Contains(strings.ToLower(out[i].Reason), strings.ToLower(cr.Reason)) {
+			if out[i].Reason == "" {
+				out[i].Reason = cr.Reason
+			} else {
+				out[i].Reason += "; " + cr.Reason
+			}
+		}
+	}
+	return out
 }
 
 func clamp(v, lo, hi int) int {
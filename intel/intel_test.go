@@ -0,0 +1,40 @@
+package intel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBriefResponseDuplicateRisks(t *testing.T) {
+	content := `SUMMARY:
+Fighting continued.
+
+THREATS:
+• Drone strikes on energy sites
+- Shipping disruption
+
+COUNTRY_RISKS:
+Ukraine|90|drone strikes
+ukraine |85|energy grid
+USA|40|election tension
+United States|55|election tension
+Sudan|80|civil war
+Nowhere|not a score|ignored
+`
+	summary, threats, risks := parseBriefResponse(content)
+	if summary != "Fighting continued." {
+		t.Errorf("summary = %q", summary)
+	}
+	wantThreats := []string{"Drone strikes on energy sites", "Shipping disruption"}
+	if !reflect.DeepEqual(threats, wantThreats) {
+		t.Errorf("threats = %q, want %q", threats, wantThreats)
+	}
+	want := []CountryRisk{
+		{Country: "Ukraine", Score: 90, Reason: "drone strikes; energy grid"},
+		{Country: "United States", Score: 55, Reason: "election tension"},
+		{Country: "Sudan", Score: 80, Reason: "civil war"},
+	}
+	if !reflect.DeepEqual(risks, want) {
+		t.Errorf("risks = %+v, want %+v", risks, want)
+	}
+}
@@ -0,0 +1,18 @@
+package intel
+
+import (
+	"errors"
+	"strings"
+	"watchtower/config"
+)
+
+// redactError scrubs the API key from err's message in case a provider or
+// the transport echoes it back (e.g. in a URL or an error body), so it
+// can't end up on screen or in a bug report. Each httpSummarizer redacts its
+// own key, which covers every provider in a fallback chain.
+func redactError(err error, key string) error {
+	if err == nil || key == "" || !strings.Contains(err.Error(), key) {
+		return err
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), key, config.MaskSecret(key)))
+}
@@ -0,0 +1,272 @@
+package intel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Summarizer sends one prompt to an LLM and returns its raw text answer and
+// the model that produced it. system frames the model's role; adapters for
+// APIs without a separate system field leave it out, as before.
+type Summarizer interface {
+	Complete(ctx context.Context, system, prompt string, maxTokens int) (text, model string, err error)
+}
+
+// adapter translates a completion to and from one provider's wire format.
+// Adding an OpenAI-compatible provider only needs a providerDefaults entry;
+// a new API shape needs one adapter.
+type adapter interface {
+	// body returns the JSON request body
+	body(model, system, prompt string, maxTokens int) any
+	// header sets any provider-specific headers besides auth and content type
+	header(h http.Header)
+	// decode extracts the answer and, if the API reports it, the model;
+	// p names the provider in errors
+	decode(r io.Reader, p Provider) (text, model string, err error)
+}
+
+// summarizer returns the Summarizer for the configured provider. Unknown
+// providers are treated as OpenAI-compatible.
+func (c LLMConfig) summarizer() Summarizer {
+	if len(c.Fallbacks) > 0 {
+		chain := fallbackSummarizer{c.single().summarizer()}
+		for _, f := range c.Fallbacks {
+			chain = append(chain, f.single().summarizer())
+		}
+		return chain
+	}
+	a := providerDefaults[c.Provider].adapter
+	if a == nil {
+		a = openAIAdapter{}
+	}
+	return httpSummarizer{cfg: c, adapter: a}
+}
+
+// single returns c without its fallbacks
+func (c LLMConfig) single() LLMConfig {
+	c.Fallbacks = nil
+	return c
+}
+
+// fallbackSummarizer tries each provider in turn and returns the first
+// answer. When all fail the error lists every provider's failure, e.g.
+// "all providers failed: groq HTTP 429, openai HTTP 401".
+type fallbackSummarizer []Summarizer
+
+func (f fallbackSummarizer) Complete(ctx context.Context, system, prompt string, maxTokens int) (string, string, error) {
+	var errs []string
+	for _, s := range f {
+		text, model, err := s.Complete(ctx, system, prompt, maxTokens)
+		if err == nil {
+			return text, model, nil
+		}
+		errs = append(errs, err.Error())
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return "", "", fmt.Errorf("all providers failed: %s", strings.Join(errs, ", "))
+}
+
+// httpSummarizer is the request/response plumbing shared by every provider
+type httpSummarizer struct {
+	cfg     LLMConfig
+	adapter adapter
+}
+
+// Complete scrubs this provider's key from any error, so a fallback chain's
+// combined error can't leak a key the primary provider doesn't know about
+func (s httpSummarizer) Complete(ctx context.Context, system, prompt string, maxTokens int) (string, string, error) {
+	text, model, err := s.complete(ctx, system, prompt, maxTokens)
+	return text, model, redactError(err, s.cfg.APIKey)
+}
+
+func (s httpSummarizer) complete(ctx context.Context, system, prompt string, maxTokens int) (string, string, error) {
+	cfg := s.cfg
+	bodyBytes, err := json.Marshal(s.adapter.body(cfg.ModelName(), system, prompt, maxTokens))
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", cfg.Endpoint(), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set(cfg.AuthHeader(), cfg.AuthValue())
+	req.Header.Set("Content-Type", "application/json")
+	s.adapter.header(req.Header)
+	// Gateway headers come last so they can replace the provider's own
+	for k, v := range cfg.Gateway.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("%s request failed: %w", cfg.Provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return "", "", fmt.Errorf("%s HTTP %d", cfg.Provider, resp.StatusCode)
+	}
+
+	text, model, err := s.adapter.decode(resp.Body, cfg.Provider)
+	if err != nil {
+		return "", "", err
+	}
+	if model == "" {
+		model = cfg.ModelName()
+	}
+	return text, model, nil
+}
+
+// ─── OpenAI-compatible (Groq, OpenAI, DeepSeek, OpenRouter, Azure, local) ────
+
+type openAIAdapter struct {
+	headers map[string]string // extra headers some gateways ask for
+}
+
+func (openAIAdapter) body(model, _, prompt string, maxTokens int) any {
+	return map[string]interface{}{
+		"model":       model,
+		"temperature": 0,
+		"max_tokens":  maxTokens,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+	}
+}
+
+func (a openAIAdapter) header(h http.Header) {
+	for k, v := range a.headers {
+		h.Set(k, v)
+	}
+}
+
+func (openAIAdapter) decode(r io.Reader, p Provider) (string, string, error) {
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Model string `json:"model"`
+	}
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return "", "", fmt.Errorf("decoding %s response: %w", p, err)
+	}
+	if len(result.Choices) == 0 {
+		return "", "", fmt.Errorf("no response from %s", p)
+	}
+	return result.Choices[0].Message.Content, result.Model, nil
+}
+
+// ─── Anthropic messages API ──────────────────────────────────────────────────
+
+type claudeAdapter struct{}
+
+func (claudeAdapter) body(model, system, prompt string, maxTokens int) any {
+	return map[string]interface{}{
+		"model":       model,
+		"max_tokens":  maxTokens,
+		"temperature": 0,
+		"system":      system,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+	}
+}
+
+func (claudeAdapter) header(h http.Header) {
+	h.Set("anthropic-version", "2023-06-01")
+}
+
+func (claudeAdapter) decode(r io.Reader, p Provider) (string, string, error) {
+	var result struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return "", "", fmt.Errorf("decoding %s response: %w", p, err)
+	}
+	if len(result.Content) == 0 {
+		return "", "", fmt.Errorf("no response from %s", p)
+	}
+	return result.Content[0].Text, "", nil
+}
+
+// ─── Gemini generateContent ──────────────────────────────────────────────────
+
+type geminiAdapter struct{}
+
+func (geminiAdapter) body(_, _, prompt string, maxTokens int) any {
+	return map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{
+				"parts": []map[string]string{
+					{"text": prompt},
+				},
+			},
+		},
+		"generationConfig": map[string]interface{}{
+			"temperature":     0,
+			"maxOutputTokens": maxTokens,
+		},
+	}
+}
+
+func (geminiAdapter) header(http.Header) {}
+
+func (geminiAdapter) decode(r io.Reader, p Provider) (string, string, error) {
+	var result geminiResponse
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return "", "", fmt.Errorf("decoding %s response: %w", p, err)
+	}
+	text, err := result.text()
+	return text, "", err
+}
+
+// geminiResponse is the subset of a generateContent reply watchtower reads.
+// A blocked prompt comes back with no candidates and a promptFeedback
+// blockReason; a blocked answer with a finishReason such as SAFETY and no
+// parts.
+type geminiResponse struct {
+	Candidates []struct {
+		Content struct {
+			Parts []struct {
+				Text string `json:"text"`
+			} `json:"parts"`
+		} `json:"content"`
+		FinishReason string `json:"finishReason"`
+	} `json:"candidates"`
+	PromptFeedback struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+}
+
+// text returns the first candidate's text, or an error explaining why
+// Gemini sent none.
+func (r geminiResponse) text() (string, error) {
+	if len(r.Candidates) == 0 {
+		if reason := r.PromptFeedback.BlockReason; reason != "" {
+			return "", fmt.Errorf("gemini blocked the prompt (%s)", reason)
+		}
+		return "", fmt.Errorf("no response from gemini")
+	}
+	c := r.Candidates[0]
+	if len(c.Content.Parts) == 0 {
+		switch c.FinishReason {
+		case "", "STOP":
+			return "", fmt.Errorf("no response from gemini")
+		default:
+			return "", fmt.Errorf("gemini blocked response (%s)", c.FinishReason)
+		}
+	}
+	return c.Content.Parts[0].Text, nil
+}
@@ -0,0 +1,151 @@
+package intel
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSummarizerAdapter(t *testing.T) {
+	tests := []struct {
+		provider Provider
+		want     adapter
+	}{
+		{ProviderGroq, openAIAdapter{}},
+		{ProviderOpenAI, openAIAdapter{}},
+		{ProviderDeepSeek, openAIAdapter{}},
+		{ProviderLocal, openAIAdapter{}},
+		{ProviderAzure, openAIAdapter{}},
+		{ProviderGemini, geminiAdapter{}},
+		{ProviderClaude, claudeAdapter{}},
+		{ProviderOpenRouter, openAIAdapter{headers: map[string]string{
+			"HTTP-Referer": "https://github.com/lajosdeme/watchtower",
+			"X-Title":      "Watchtower",
+		}}},
+		{"some-new-provider", openAIAdapter{}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.provider), func(t *testing.T) {
+			s, ok := LLMConfig{Provider: tt.provider}.summarizer().(httpSummarizer)
+			if !ok {
+				t.Fatalf("summarizer is %T, want httpSummarizer", LLMConfig{Provider: tt.provider}.summarizer())
+			}
+			if !reflect.DeepEqual(s.adapter, tt.want) {
+				t.Errorf("adapter = %#v, want %#v", s.adapter, tt.want)
+			}
+		})
+	}
+
+	chain, ok := LLMConfig{Provider: ProviderGroq, Fallbacks: []LLMConfig{{Provider: ProviderClaude}}}.summarizer().(fallbackSummarizer)
+	if !ok || len(chain) != 2 {
+		t.Fatalf("with a fallback: got %#v, want a two-provider fallbackSummarizer", chain)
+	}
+}
+
+// TestSummarizerWireFormat checks the request each adapter sends and that
+// the answer is read back, against a stand-in server
+func TestSummarizerWireFormat(t *testing.T) {
+	tests := []struct {
+		provider Provider
+		model    string
+		wantBody string
+		wantHdr  map[string]string
+		reply    string
+		wantText string
+	}{
+		{
+			ProviderGroq, "",
+			`{"max_tokens":100,"messages":[{"content":"the prompt","role":"user"}],"model":"llama-3.1-8b-instant","temperature":0}`,
+			map[string]string{"Authorization": "Bearer key", "Content-Type": "application/json"},
+			`{"choices":[{"message":{"content":"groq says"}}],"model":"llama-3.1-8b-instant"}`,
+			"groq says",
+		},
+		{
+			ProviderOpenRouter, "",
+			`{"max_tokens":100,"messages":[{"content":"the prompt","role":"user"}],"model":"openai/gpt-4o-mini","temperature":0}`,
+			map[string]string{"Authorization": "Bearer key", "Http-Referer": "https://github.com/lajosdeme/watchtower", "X-Title": "Watchtower"},
+			`{"choices":[{"message":{"content":"router says"}}]}`,
+			"router says",
+		},
+		{
+			ProviderClaude, "",
+			`{"max_tokens":100,"messages":[{"content":"the prompt","role":"user"}],"model":"claude-3-haiku-20240307","system":"be brief","temperature":0}`,
+			map[string]string{"X-Api-Key": "key", "Anthropic-Version": "2023-06-01"},
+			`{"content":[{"text":"claude says"}]}`,
+			"claude says",
+		},
+		{
+			ProviderGemini, "gemini-1.5-flash",
+			`{"contents":[{"parts":[{"text":"the prompt"}]}],"generationConfig":{"maxOutputTokens":100,"temperature":0}}`,
+			map[string]string{"X-Goog-Api-Key": "key"},
+			`{"candidates":[{"content":{"parts":[{"text":"gemini says"}]}}]}`,
+			"gemini says",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.provider), func(t *testing.T) {
+			var gotBody []byte
+			var gotHdr http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotBody, _ = io.ReadAll(r.Body)
+				gotHdr = r.Header
+				io.WriteString(w, tt.reply)
+			}))
+			defer srv.Close()
+
+			cfg := LLMConfig{Provider: tt.provider, APIKey: "key", Model: tt.model, Gateway: Gateway{Endpoint: srv.URL}}
+			text, _, err := cfg.summarizer().Complete(context.Background(), "be brief", "the prompt", 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if text != tt.wantText {
+				t.Errorf("text = %q, want %q", text, tt.wantText)
+			}
+			var got, want any
+			if err := json.Unmarshal(gotBody, &got); err != nil {
+				t.Fatalf("request body %s: %v", gotBody, err)
+			}
+			json.Unmarshal([]byte(tt.wantBody), &want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("body = %s\nwant   %s", gotBody, tt.wantBody)
+			}
+			for k, v := range tt.wantHdr {
+				if gotHdr.Get(k) != v {
+					t.Errorf("header %s = %q, want %q", k, gotHdr.Get(k), v)
+				}
+			}
+		})
+	}
+}
+
+// TestFallbackRedactsEveryKey checks a fallback provider's key echoed back
+// in its error doesn't reach the combined "all providers failed" message
+func TestFallbackRedactsEveryKey(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	dead := srv.URL
+	srv.Close()
+
+	const primaryKey, fallbackKey = "primary-secret-key-1234", "fallback-secret-key-5678"
+	cfg := LLMConfig{
+		Provider: ProviderGroq, APIKey: primaryKey,
+		Gateway: Gateway{Endpoint: dead + "/v1?key=" + primaryKey},
+		Fallbacks: []LLMConfig{{
+			Provider: ProviderOpenAI, APIKey: fallbackKey,
+			Gateway: Gateway{Endpoint: dead + "/v1?key=" + fallbackKey},
+		}},
+	}
+	_, _, err := cfg.summarizer().Complete(context.Background(), "", "prompt", 10)
+	if err == nil {
+		t.Fatal("expected an error from unreachable providers")
+	}
+	for _, key := range []string{primaryKey, fallbackKey} {
+		if strings.Contains(err.Error(), key) {
+			t.Errorf("error leaks %q: %v", key, err)
+		}
+	}
+}
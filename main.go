@@ -1,8 +1,11 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"watchtower/config"
 	"watchtower/ui"
 
@@ -15,11 +18,36 @@ var (
 	date    = "unknown"
 )
 
+// debug is set by --debug: print fetch timings when the dashboard quits
+var debug bool
+
 func main() {
-	// handle --version / -v
-	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "-v") {
-		fmt.Printf("watchtower %s (commit: %s, built: %s)\n", version, commit, date)
-		os.Exit(0)
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "--version", "-v":
+			fmt.Printf("watchtower %s (commit: %s, built: %s)\n", version, commit, date)
+			os.Exit(0)
+		case "brief":
+			runBrief(os.Args[2:])
+			return
+		case "snapshot":
+			runSnapshot(os.Args[2:])
+			return
+		case "digest":
+			runDigest(os.Args[2:])
+			return
+		case "risk-history":
+			runRiskHistory(os.Args[2:])
+			return
+		case "init":
+			runInit(os.Args[2:])
+			return
+		case "reset":
+			runReset(os.Args[2:])
+			return
+		case "--debug":
+			debug = true
+		}
 	}
 
 	if !config.ConfigExists() {
@@ -28,21 +56,46 @@ func main() {
 	}
 
 	cfg, err := config.Load()
+	if errors.Is(err, config.ErrInvalid) {
+		if !offerSetup(err) {
+			os.Exit(1)
+		}
+		runSetup()
+		return
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
+	for _, w := range cfg.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	if cfg.SpinnerStyle != "" && !ui.SpinnerStyleValid(cfg.SpinnerStyle) {
+		fmt.Fprintf(os.Stderr, "Warning: unknown spinner_style %q; using dot\n", cfg.SpinnerStyle)
+	}
+	if !ui.LanguageSupported(cfg.Language) {
+		fmt.Fprintf(os.Stderr, "Warning: no translations for language %q; using English\n", cfg.Language)
+	}
 
-	p := tea.NewProgram(
-		ui.NewModel(cfg),
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
+	runDashboard(cfg)
+}
 
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
+// offerSetup explains a broken config and asks whether to re-run setup.
+// On yes the broken file is backed up first, so nothing is lost.
+func offerSetup(loadErr error) bool {
+	fmt.Fprintf(os.Stderr, "Error loading config: %v\n", loadErr)
+	fmt.Fprint(os.Stderr, "Back it up and run setup again? [Y/n] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if a := strings.ToLower(strings.TrimSpace(answer)); a != "" && a != "y" && a != "yes" {
+		return false
+	}
+	backup, err := config.BackupBroken()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	fmt.Fprintf(os.Stderr, "Saved the broken config to %s\n", backup)
+	return true
 }
 
 func runSetup() {
@@ -62,14 +115,26 @@ func runSetup() {
 		os.Exit(1)
 	}
 
-	p = tea.NewProgram(
+	runDashboard(cfg)
+}
+
+func runDashboard(cfg *config.Config) {
+	pruneCache(cfg)
+	p := tea.NewProgram(
 		ui.NewModel(cfg),
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
 
-	if _, err := p.Run(); err != nil {
+	enable, disable := ui.FocusReporting(cfg)
+	fmt.Print(enable)
+	final, err := p.Run()
+	fmt.Print(disable)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
 		os.Exit(1)
 	}
+	if m, ok := final.(ui.Model); ok && debug {
+		fmt.Fprintln(os.Stderr, m.FetchSummary())
+	}
 }
@@ -0,0 +1,127 @@
+package markets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// binanceSymbols maps CoinGecko ids to Binance base assets and display names.
+// Binance quotes against USDT, so ids missing here are skipped.
+var binanceSymbols = map[string]struct{ symbol, name string }{
+	"bitcoin":           {"BTC", "Bitcoin"},
+	"ethereum":          {"ETH", "Ethereum"},
+	"dogecoin":          {"DOGE", "Dogecoin"},
+	"usd-coin":          {"USDC", "USDC"},
+	"binancecoin":       {"BNB", "BNB"},
+	"ripple":            {"XRP", "XRP"},
+	"solana":            {"SOL", "Solana"},
+	"cardano":           {"ADA", "Cardano"},
+	"tron":              {"TRX", "TRON"},
+	"avalanche-2":       {"AVAX", "Avalanche"},
+	"polkadot":          {"DOT", "Polkadot"},
+	"chainlink":         {"LINK", "Chainlink"},
+	"litecoin":          {"LTC", "Litecoin"},
+	"shiba-inu":         {"SHIB", "Shiba Inu"},
+	"uniswap":           {"UNI", "Uniswap"},
+	"matic-network":     {"MATIC", "Polygon"},
+	"pepe":              {"PEPE", "Pepe"},
+	"aave":              {"AAVE", "Aave"},
+	"dai":               {"DAI", "Dai"},
+	"maker":             {"MKR", "Maker"},
+	"lido-dao":          {"LDO", "Lido DAO"},
+	"floki":             {"FLOKI", "FLOKI"},
+	"bonk":              {"BONK", "Bonk"},
+	"dogwifcoin":        {"WIF", "dogwifhat"},
+	"first-digital-usd": {"FDUSD", "First Digital USD"},
+}
+
+type binance struct{}
+
+func (binance) Name() string { return "binance" }
+
+// FetchPrices fetches 24h tickers from Binance's public API. It has no
+// market cap data, so MarketCapUSD is left at zero.
+func (binance) FetchPrices(ctx context.Context, ids []string) ([]CryptoPrice, error) {
+	var pairs []string
+	bySymbol := map[string]string{} // "BTCUSDT" → "bitcoin"
+	for _, id := range ids {
+		s, ok := binanceSymbols[id]
+		if !ok {
+			continue
+		}
+		pair := s.symbol + "USDT"
+		pairs = append(pairs, `"`+pair+`"`)
+		bySymbol[pair] = id
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("none of the configured coins are known to binance")
+	}
+
+	endpoint := "https://api.binance.com/api/v3/ticker/24hr?symbols=" +
+		url.QueryEscape("["+strings.Join(pairs, ",")+"]")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("binance request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 429 || resp.StatusCode == 418 {
+		return nil, fmt.Errorf("binance rate limited")
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("binance HTTP %d", resp.StatusCode)
+	}
+
+	var raw []struct {
+		Symbol             string `json:"symbol"`
+		LastPrice          string `json:"lastPrice"`
+		PriceChangePercent string `json:"priceChangePercent"`
+		QuoteVolume        string `json:"quoteVolume"`
+		CloseTime          int64  `json:"closeTime"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding binance response: %w", err)
+	}
+
+	// Keep the caller's id order, like CoinGecko's market-cap sort keeps it stable
+	byID := map[string]CryptoPrice{}
+	for _, r := range raw {
+		id, ok := bySymbol[r.Symbol]
+		if !ok {
+			continue
+		}
+		price, _ := strconv.ParseFloat(r.LastPrice, 64)
+		change, _ := strconv.ParseFloat(r.PriceChangePercent, 64)
+		vol, _ := strconv.ParseFloat(r.QuoteVolume, 64)
+		byID[id] = CryptoPrice{
+			ID:           id,
+			Symbol:       binanceSymbols[id].symbol,
+			Name:         binanceSymbols[id].name,
+			PriceUSD:     price,
+			Change24h:    change,
+			Volume24hUSD: vol,
+			LastUpdated:  time.UnixMilli(r.CloseTime),
+		}
+	}
+
+	prices := make([]CryptoPrice, 0, len(byID))
+	for _, id := range ids {
+		if p, ok := byID[id]; ok {
+			prices = append(prices, p)
+		}
+	}
+	return prices, nil
+}
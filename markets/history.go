@@ -0,0 +1,122 @@
+package markets
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"watchtower/paths"
+)
+
+// PriceHistory keeps the last Size prices observed per asset across
+// refreshes, oldest first. It is watchtower's own record, so it works for
+// every source, not only those that publish sparklines.
+type PriceHistory struct {
+	Size   int                  `json:"size"`
+	Series map[string][]float64 `json:"series"`
+}
+
+// NewPriceHistory returns an empty history holding size points per asset
+func NewPriceHistory(size int) *PriceHistory {
+	return &PriceHistory{Size: size, Series: make(map[string][]float64)}
+}
+
+// Record appends a price for key, dropping the oldest beyond Size
+func (h *PriceHistory) Record(key string, price float64) {
+	if h == nil || h.Size <= 0 || price <= 0 {
+		return
+	}
+	s := append(h.Series[key], price)
+	if len(s) > h.Size {
+		s = s[len(s)-h.Size:]
+	}
+	h.Series[key] = s
+}
+
+// Values returns the recorded prices for key, oldest first
+func (h *PriceHistory) Values(key string) []float64 {
+	if h == nil {
+		return nil
+	}
+	return h.Series[key]
+}
+
+// Snapshot returns a deep copy, safe to save while the original keeps
+// recording.
+func (h *PriceHistory) Snapshot() *PriceHistory {
+	c := NewPriceHistory(h.Size)
+	for key, s := range h.Series {
+		c.Series[key] = append([]float64(nil), s...)
+	}
+	return c
+}
+
+func priceHistoryFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := paths.CacheDir(home)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "price_history.json"), nil
+}
+
+// LoadPriceHistory reads the persisted history, trimmed to size. A missing
+// or unreadable file yields an empty history.
+func LoadPriceHistory(size int) *PriceHistory {
+	h := NewPriceHistory(size)
+	if size <= 0 {
+		return h
+	}
+	path, err := priceHistoryFilePath()
+	if err != nil {
+		return h
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return h
+	}
+	var saved PriceHistory
+	if json.Unmarshal(data, &saved) != nil {
+		return h
+	}
+	for key, s := range saved.Series {
+		if len(s) > size {
+			s = s[len(s)-size:]
+		}
+		h.Series[key] = s
+	}
+	return h
+}
+
+// Save persists the history for the next run
+func (h *PriceHistory) Save() error {
+	path, err := priceHistoryFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ClearPriceHistory deletes the persisted history. It returns the path it
+// removed, or "" if none was saved.
+func ClearPriceHistory() (string, error) {
+	path, err := priceHistoryFilePath()
+	if err != nil {
+		return "", err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
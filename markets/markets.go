@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"watchtower/fixtures"
 )
 
 // ─── Types ────────────────────────────────────────────────────────────────────
@@ -32,6 +36,7 @@ type StockIndex struct {
 	Price     float64
 	PrevClose float64
 	ChangePct float64
+	QuoteTime
 }
 
 // Commodity holds price data for a commodity (oil, gold, etc.)
@@ -40,32 +45,192 @@ type Commodity struct {
 	Name      string
 	Price     float64
 	PrevClose float64
-	Unit      string // e.g. "$/bbl", "$/oz", "$/t"
+	Unit      string // trade unit, e.g. "bbl", "oz", "kg"
+	Currency  string // ISO code the prices are in, e.g. "USD"
 	ChangePct float64
+	QuoteTime
+}
+
+// QuoteTime says how fresh a delayed quote is. Yahoo reports the last trade
+// time and the regular session; Stooq reports neither, so only Fetched is
+// known for its quotes.
+type QuoteTime struct {
+	Updated time.Time // last trade; zero if the source didn't say
+	Fetched time.Time // when watchtower received the quote
+
+	// Regular session current at fetch time (or the next one); zero if unknown
+	SessionOpen, SessionClose time.Time
+}
+
+// Age is how old the price is: since the last trade if known, otherwise
+// since the fetch. Zero if neither is known.
+func (q QuoteTime) Age(now time.Time) time.Duration {
+	t := q.Updated
+	if t.IsZero() {
+		t = q.Fetched
+	}
+	if t.IsZero() {
+		return 0
+	}
+	return now.Sub(t)
+}
+
+// Stale reports whether the last trade is older than maxAge while the market
+// is open. Outside the session (nights, weekends) an old price is expected.
+func (q QuoteTime) Stale(now time.Time, maxAge time.Duration) bool {
+	if maxAge <= 0 || q.Updated.IsZero() {
+		return false
+	}
+	if !q.SessionOpen.IsZero() && (now.Before(q.SessionOpen) || !now.Before(q.SessionClose)) {
+		return false
+	}
+	return now.Sub(q.Updated) > maxAge
+}
+
+// CryptoStale reports whether p is older than maxAge. Crypto trades around
+// the clock, so there is no session to excuse an old price.
+func CryptoStale(p CryptoPrice, now time.Time, maxAge time.Duration) bool {
+	return maxAge > 0 && !p.LastUpdated.IsZero() && now.Sub(p.LastUpdated) > maxAge
+}
+
+// UnitLabel returns the price unit for display, e.g. "$/oz" or "€/g"
+func (c Commodity) UnitLabel() string {
+	return strings.TrimSpace(CurrencySymbol(c.Currency)) + "/" + c.Unit
 }
 
 // PredictionMarket holds a Polymarket market
 type PredictionMarket struct {
-	Title       string
-	Probability float64 // 0.0 to 1.0
-	Volume      float64
-	Category    string
-	EndDate     string
-	Slug        string
+	Title          string
+	Probability    float64 // 0.0 to 1.0; only meaningful if HasProbability
+	HasProbability bool    // false when outcomePrices couldn't be parsed
+	Volume         float64
+	HasVolume      bool
+	Category       string
+	EndDate        string
+	Slug           string
+	Outcomes       []Outcome // every outcome with its price, in API order
+}
+
+// Outcome is one possible result of a prediction market
+type Outcome struct {
+	Name        string
+	Probability float64
+}
+
+// URL returns the market's Polymarket page, or "" when it has no slug
+func (p PredictionMarket) URL() string {
+	if p.Slug == "" {
+		return ""
+	}
+	return "https://polymarket.com/market/" + p.Slug
 }
 
 var httpClient = &http.Client{Timeout: 15 * time.Second}
 
 // ─── Crypto ───────────────────────────────────────────────────────────────────
 
-// FetchCryptoPrices fetches prices for the given CoinGecko IDs
-func FetchCryptoPrices(ctx context.Context, ids []string) ([]CryptoPrice, error) {
+// CryptoProvider is a source of crypto prices. Coins are always requested
+// by CoinGecko id; providers translate ids to their own symbols.
+type CryptoProvider interface {
+	Name() string
+	FetchPrices(ctx context.Context, ids []string) ([]CryptoPrice, error)
+}
+
+// CryptoProfile is a named starter list of CoinGecko ids offered during
+// setup. The ids are written to crypto_pairs, so they stay editable.
+type CryptoProfile struct {
+	Name string
+	IDs  []string
+}
+
+// CryptoProfiles are the setup presets, in display order
+var CryptoProfiles = []CryptoProfile{
+	{"default", []string{"bitcoin", "ethereum", "dogecoin", "usd-coin"}},
+	{"majors", []string{"bitcoin", "ethereum", "binancecoin", "solana", "ripple", "cardano"}},
+	{"defi", []string{"ethereum", "uniswap", "aave", "chainlink", "maker", "lido-dao"}},
+	{"memes", []string{"dogecoin", "shiba-inu", "pepe", "dogwifcoin", "bonk", "floki"}},
+	{"stablecoins", []string{"tether", "usd-coin", "dai", "first-digital-usd"}},
+}
+
+// CryptoOptions selects the crypto price source and its credentials. The
+// zero value uses CoinGecko's free public API.
+type CryptoOptions struct {
+	Primary      string // "coingecko" or "binance"; the other is the fallback
+	CoinGeckoKey string // demo or pro API key; "" = anonymous
+	CoinGeckoPro bool   // key is a paid-tier key: use the pro host
+}
+
+// cryptoProviders lists the available providers by config name
+func cryptoProviders(opts CryptoOptions) map[string]CryptoProvider {
+	return map[string]CryptoProvider{
+		"coingecko": coinGecko{apiKey: opts.CoinGeckoKey, pro: opts.CoinGeckoPro && opts.CoinGeckoKey != ""},
+		"binance":   binance{},
+	}
+}
+
+// FetchCryptoPrices fetches prices for the given CoinGecko IDs from the
+// primary provider, falling back to the others (in a stable order) if it
+// fails — CoinGecko's free tier rate-limits aggressively.
+func FetchCryptoPrices(ctx context.Context, opts CryptoOptions, ids []string) ([]CryptoPrice, error) {
+	var canned []CryptoPrice
+	if ok, err := fixtures.Load("crypto", &canned); ok {
+		return canned, err
+	}
+	primary := opts.Primary
+	if primary == "" {
+		primary = "coingecko"
+	}
+	providers := cryptoProviders(opts)
+	order := []string{primary}
+	for _, name := range []string{"coingecko", "binance"} {
+		if name != primary {
+			order = append(order, name)
+		}
+	}
+
+	var errs []string
+	for _, name := range order {
+		p, ok := providers[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("unknown crypto provider %q", name))
+			continue
+		}
+		prices, err := p.FetchPrices(ctx, ids)
+		if err == nil && len(prices) > 0 {
+			return prices, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("no prices returned")
+		}
+		errs = append(errs, p.Name()+": "+err.Error())
+	}
+	return nil, fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+// coinGecko talks to the free public API, or with a paid-tier key to the
+// pro host, which has far higher rate limits. Demo keys use the public host.
+type coinGecko struct {
+	apiKey string
+	pro    bool
+}
+
+func (coinGecko) Name() string { return "coingecko" }
+
+func (c coinGecko) host() string {
+	if c.pro {
+		return "https://pro-api.coingecko.com"
+	}
+	return "https://api.coingecko.com"
+}
+
+// FetchPrices fetches prices for the given CoinGecko IDs
+func (c coinGecko) FetchPrices(ctx context.Context, ids []string) ([]CryptoPrice, error) {
 	joined := strings.Join(ids, ",")
 	url := fmt.Sprintf(
-		"https://api.coingecko.com/api/v3/coins/markets?vs_currency=usd&ids=%s"+
+		"%s/api/v3/coins/markets?vs_currency=usd&ids=%s"+
 			"&order=market_cap_desc&per_page=20&page=1&sparkline=false"+
 			"&price_change_percentage=24h",
-		joined,
+		c.host(), joined,
 	)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -73,6 +238,12 @@ func FetchCryptoPrices(ctx context.Context, ids []string) ([]CryptoPrice, error)
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
+	switch {
+	case c.pro:
+		req.Header.Set("x-cg-pro-api-key", c.apiKey)
+	case c.apiKey != "":
+		req.Header.Set("x-cg-demo-api-key", c.apiKey)
+	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
@@ -83,6 +254,14 @@ func FetchCryptoPrices(ctx context.Context, ids []string) ([]CryptoPrice, error)
 	if resp.StatusCode == 429 {
 		return nil, fmt.Errorf("CoinGecko rate limited (try again in ~1min)")
 	}
+	if c.apiKey != "" && (resp.StatusCode == 400 || resp.StatusCode == 401 || resp.StatusCode == 403) {
+		// CoinGecko answers a key used on the wrong host this way too
+		tier := "demo"
+		if c.pro {
+			tier = "pro"
+		}
+		return nil, fmt.Errorf("coingecko rejected the %s API key (HTTP %d); check coingecko_pro matches the key's plan", tier, resp.StatusCode)
+	}
 	if resp.StatusCode != 200 {
 		return nil, fmt.Errorf("coingecko HTTP %d", resp.StatusCode)
 	}
@@ -120,89 +299,22 @@ func FetchCryptoPrices(ctx context.Context, ids []string) ([]CryptoPrice, error)
 	return prices, nil
 }
 
-// ─── Yahoo Finance chart endpoint ────────────────────────────────────────────
-// Uses the same v8/finance/chart endpoint as:
-//   curl -s -L "https://query1.finance.yahoo.com/v8/finance/chart/%5EGSPC" \
-//        -H "User-Agent: Mozilla/5.0"
-// The meta object contains regularMarketPrice, previousClose, and
-// regularMarketChangePercent — everything we need in one request.
-
-type yahooMeta struct {
-	RegularMarketPrice         float64 `json:"regularMarketPrice"`
-	PreviousClose              float64 `json:"previousClose"`
-	RegularMarketChangePercent float64 `json:"regularMarketChangePercent"`
-	ChartPreviousClose         float64 `json:"chartPreviousClose"`
-	Symbol                     string  `json:"symbol"`
-}
-
-func fetchYahooChart(ctx context.Context, symbol string) (yahooMeta, error) {
-	url := "https://query1.finance.yahoo.com/v8/finance/chart/" + symbol
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return yahooMeta{}, err
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return yahooMeta{}, fmt.Errorf("yahoo chart request for %s: %w", symbol, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return yahooMeta{}, fmt.Errorf("yahoo chart HTTP %d for %s", resp.StatusCode, symbol)
-	}
-
-	var envelope struct {
-		Chart struct {
-			Result []struct {
-				Meta yahooMeta `json:"meta"`
-			} `json:"result"`
-			Error *struct {
-				Description string `json:"description"`
-			} `json:"error"`
-		} `json:"chart"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
-		return yahooMeta{}, fmt.Errorf("decoding yahoo chart for %s: %w", symbol, err)
-	}
-	if envelope.Chart.Error != nil {
-		return yahooMeta{}, fmt.Errorf("yahoo chart error for %s: %s", symbol, envelope.Chart.Error.Description)
-	}
-	if len(envelope.Chart.Result) == 0 {
-		return yahooMeta{}, fmt.Errorf("no results from yahoo chart for %s", symbol)
-	}
-
-	meta := envelope.Chart.Result[0].Meta
-	// Compute pct change if not provided directly
-	if meta.RegularMarketChangePercent == 0 && meta.PreviousClose != 0 {
-		meta.RegularMarketChangePercent = ((meta.RegularMarketPrice - meta.PreviousClose) / meta.PreviousClose) * 100
-	}
-	// Fallback: use chartPreviousClose if previousClose is zero
-	if meta.PreviousClose == 0 && meta.ChartPreviousClose != 0 {
-		meta.PreviousClose = meta.ChartPreviousClose
-		if meta.RegularMarketChangePercent == 0 {
-			meta.RegularMarketChangePercent = ((meta.RegularMarketPrice - meta.ChartPreviousClose) / meta.ChartPreviousClose) * 100
-		}
-	}
-
-	return meta, nil
-}
-
 // ─── Stock Indices ────────────────────────────────────────────────────────────
 
-// FetchStockIndices fetches S&P 500 and Dow Jones via Yahoo Finance chart API
+// FetchStockIndices fetches S&P 500 and Dow Jones via Yahoo Finance, falling
+// back to Stooq
 func FetchStockIndices(ctx context.Context) ([]StockIndex, error) {
+	var canned []StockIndex
+	if ok, err := fixtures.Load("indices", &canned); ok {
+		return canned, err
+	}
 	type indexDef struct {
-		yahooSymbol string // URL-encoded if needed
+		symbol      quoteSymbol
 		displayName string
 	}
 	defs := []indexDef{
-		{"%5EGSPC", "S&P 500"},
-		{"%5EDJI", "Dow Jones"},
+		{quoteSymbol{"%5EGSPC", "^spx"}, "S&P 500"},
+		{quoteSymbol{"%5EDJI", "^dji"}, "Dow Jones"},
 	}
 
 	type result struct {
@@ -216,9 +328,9 @@ func FetchStockIndices(ctx context.Context) ([]StockIndex, error) {
 
 	for i, def := range defs {
 		wg.Add(1)
-		go func(i int, sym, name string) {
+		go func(i int, sym quoteSymbol, name string) {
 			defer wg.Done()
-			meta, err := fetchYahooChart(ctx, sym)
+			meta, err := fetchQuote(ctx, sym)
 			if err != nil {
 				results[i] = result{pos: i, err: err}
 				return
@@ -231,9 +343,10 @@ func FetchStockIndices(ctx context.Context) ([]StockIndex, error) {
 					Price:     meta.RegularMarketPrice,
 					PrevClose: meta.PreviousClose,
 					ChangePct: meta.RegularMarketChangePercent,
+					QuoteTime: meta.quoteTime(),
 				},
 			}
-		}(i, def.yahooSymbol, def.displayName)
+		}(i, def.symbol, def.displayName)
 	}
 
 	wg.Wait()
@@ -256,18 +369,25 @@ func FetchStockIndices(ctx context.Context) ([]StockIndex, error) {
 
 // ─── Commodities ──────────────────────────────────────────────────────────────
 
-// FetchCommodities fetches WTI crude oil, gold, and copper via Yahoo Finance chart API
+// FetchCommodities fetches WTI crude oil, gold, and copper via Yahoo Finance
+// (Stooq as fallback)
 // Tickers: CL=F (WTI crude), GC=F (gold), HG=F (copper)
-func FetchCommodities(ctx context.Context) ([]Commodity, error) {
+// Prices are converted to opts.Currency/opts.Units; if the FX rate fails the
+// USD prices are returned together with the FX error.
+func FetchCommodities(ctx context.Context, opts CommodityOptions) ([]Commodity, error) {
+	var canned []Commodity
+	if ok, err := fixtures.Load("commodities", &canned); ok {
+		return canned, err
+	}
 	type commDef struct {
-		yahooSymbol string
-		name        string
-		unit        string
+		symbol quoteSymbol
+		name   string
+		unit   string
 	}
 	defs := []commDef{
-		{"CL%3DF", "WTI Crude Oil", "$/bbl"},
-		{"GC%3DF", "Gold", "$/oz"},
-		{"HG%3DF", "Copper", "$/lb"},
+		{quoteSymbol{"CL%3DF", "cl.f"}, "WTI Crude Oil", "bbl"},
+		{quoteSymbol{"GC%3DF", "gc.f"}, "Gold", "oz"},
+		{quoteSymbol{"HG%3DF", "hg.f"}, "Copper", "lb"},
 	}
 
 	type result struct {
@@ -281,9 +401,9 @@ func FetchCommodities(ctx context.Context) ([]Commodity, error) {
 
 	for i, def := range defs {
 		wg.Add(1)
-		go func(i int, sym, name, unit string) {
+		go func(i int, sym quoteSymbol, name, unit string) {
 			defer wg.Done()
-			meta, err := fetchYahooChart(ctx, sym)
+			meta, err := fetchQuote(ctx, sym)
 			if err != nil {
 				results[i] = result{pos: i, err: err}
 				return
@@ -296,10 +416,12 @@ func FetchCommodities(ctx context.Context) ([]Commodity, error) {
 					Price:     meta.RegularMarketPrice,
 					PrevClose: meta.PreviousClose,
 					Unit:      unit,
+					Currency:  "USD",
 					ChangePct: meta.RegularMarketChangePercent,
+					QuoteTime: meta.quoteTime(),
 				},
 			}
-		}(i, def.yahooSymbol, def.name, def.unit)
+		}(i, def.symbol, def.name, def.unit)
 	}
 
 	wg.Wait()
@@ -318,13 +440,17 @@ func FetchCommodities(ctx context.Context) ([]Commodity, error) {
 	if len(commodities) == 0 && len(errs) > 0 {
 		return nil, fmt.Errorf("%s", strings.Join(errs, "; "))
 	}
-	return commodities, nil
+	return convertCommodities(ctx, commodities, opts)
 }
 
 // ─── Prediction Markets ───────────────────────────────────────────────────────
 
 // FetchPredictionMarkets fetches top geopolitical markets from Polymarket
 func FetchPredictionMarkets(ctx context.Context) ([]PredictionMarket, error) {
+	var canned []PredictionMarket
+	if ok, err := fixtures.Load("prediction_markets", &canned); ok {
+		return canned, err
+	}
 	url := "https://gamma-api.polymarket.com/markets?tag_id=100265&limit=20&closed=false&active=true&order=volume&ascending=false"
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -344,11 +470,12 @@ func FetchPredictionMarkets(ctx context.Context) ([]PredictionMarket, error) {
 	}
 
 	var raw []struct {
-		Question      string `json:"question"`
-		OutcomePrices string `json:"outcomePrices"`
-		Volume        string `json:"volume"`
-		EndDateIso    string `json:"endDateIso"`
-		Slug          string `json:"slug"`
+		Question      string          `json:"question"`
+		Outcomes      string          `json:"outcomes"`
+		OutcomePrices string          `json:"outcomePrices"`
+		Volume        json.RawMessage `json:"volume"`
+		EndDateIso    string          `json:"endDateIso"`
+		Slug          string          `json:"slug"`
 		Tags          []struct {
 			Slug string `json:"slug"`
 		} `json:"tags"`
@@ -363,15 +490,27 @@ func FetchPredictionMarkets(ctx context.Context) ([]PredictionMarket, error) {
 		if r.Question == "" {
 			continue
 		}
-		prob := 0.5
+		// Don't fabricate a 50% when the prices are malformed — mark unknown
+		var prob float64
+		hasProb := false
 		var prices []string
 		if err := json.Unmarshal([]byte(r.OutcomePrices), &prices); err == nil && len(prices) > 0 {
-			if f, err := strconv.ParseFloat(prices[0], 64); err == nil {
-				prob = f
+			if f, ok := parseNumber(prices[0]); ok && f >= 0 && f <= 1 {
+				prob, hasProb = f, true
+			}
+		}
+		vol, hasVol := parseRawNumber(r.Volume)
+
+		// outcomes is a JSON-encoded string array, parallel to outcomePrices
+		var names []string
+		var outcomes []Outcome
+		if json.Unmarshal([]byte(r.Outcomes), &names) == nil && len(names) == len(prices) {
+			for i, name := range names {
+				if f, ok := parseNumber(prices[i]); ok {
+					outcomes = append(outcomes, Outcome{Name: name, Probability: f})
+				}
 			}
 		}
-		var vol float64
-		fmt.Sscanf(r.Volume, "%f", &vol)
 
 		cat := "politics"
 		if len(r.Tags) > 0 {
@@ -382,29 +521,77 @@ func FetchPredictionMarkets(ctx context.Context) ([]PredictionMarket, error) {
 			endDate = r.EndDateIso[:10]
 		}
 		result = append(result, PredictionMarket{
-			Title:       r.Question,
-			Probability: prob,
-			Volume:      vol,
-			Category:    cat,
-			EndDate:     endDate,
-			Slug:        r.Slug,
+			Title:          r.Question,
+			Probability:    prob,
+			HasProbability: hasProb,
+			Volume:         vol,
+			HasVolume:      hasVol,
+			Category:       cat,
+			EndDate:        endDate,
+			Slug:           r.Slug,
+			Outcomes:       outcomes,
 		})
 	}
+
+	// Highest volume first; markets with unparseable volume keep API order at the end
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].HasVolume != result[j].HasVolume {
+			return result[i].HasVolume
+		}
+		return result[i].HasVolume && result[i].Volume > result[j].Volume
+	})
 	return result, nil
 }
 
+// parseNumber parses a string-encoded number such as "0.42" or "1.5e6"
+func parseNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+// parseRawNumber accepts a JSON number or a string-encoded number
+func parseRawNumber(raw json.RawMessage) (float64, bool) {
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return parseNumber(str)
+	}
+	var f float64
+	if err := json.Unmarshal(raw, &f); err == nil {
+		return f, true
+	}
+	return 0, false
+}
+
 // ─── Formatters ───────────────────────────────────────────────────────────────
 
 // FormatPrice returns a human-readable price string with thousands separators
 func FormatPrice(p float64) string {
+	return FormatPriceIn(p, "USD")
+}
+
+// FormatPriceIn is FormatPrice with the symbol for an ISO currency code
+func FormatPriceIn(p float64, currency string) string {
+	return CurrencySymbol(currency) + FormatLevel(p)
+}
+
+// FormatLevel formats a number the way prices are, without a currency
+// symbol, for index levels (which are points, not dollars)
+func FormatLevel(p float64) string {
 	if p >= 1000 {
-		return "$" + commaSeparate(fmt.Sprintf("%.0f", p))
+		return commaSeparate(fmt.Sprintf("%.0f", p))
 	} else if p >= 1 {
-		return fmt.Sprintf("$%.2f", p)
+		return fmt.Sprintf("%.2f", p)
 	} else if p >= 0.01 {
-		return fmt.Sprintf("$%.4f", p)
+		return fmt.Sprintf("%.4f", p)
 	} else {
-		return fmt.Sprintf("$%.6f", p)
+		return fmt.Sprintf("%.6f", p)
 	}
 }
 
@@ -0,0 +1,267 @@
+package markets
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// quote is a delayed quote for one instrument. The field names follow the
+// meta object of Yahoo's chart endpoint, the primary source.
+type quote struct {
+	RegularMarketPrice         float64 `json:"regularMarketPrice"`
+	PreviousClose              float64 `json:"previousClose"`
+	RegularMarketChangePercent float64 `json:"regularMarketChangePercent"`
+	ChartPreviousClose         float64 `json:"chartPreviousClose"`
+	Symbol                     string  `json:"symbol"`
+	RegularMarketTime          int64   `json:"regularMarketTime"` // unix seconds of the last trade
+	CurrentTradingPeriod       struct {
+		Regular struct {
+			Start int64 `json:"start"`
+			End   int64 `json:"end"`
+		} `json:"regular"`
+	} `json:"currentTradingPeriod"`
+
+	fetched time.Time
+}
+
+// quoteTime returns the freshness of q; fields the source didn't send stay zero
+func (q quote) quoteTime() QuoteTime {
+	t := QuoteTime{Fetched: q.fetched}
+	if q.RegularMarketTime > 0 {
+		t.Updated = time.Unix(q.RegularMarketTime, 0)
+	}
+	if r := q.CurrentTradingPeriod.Regular; r.Start > 0 && r.End > r.Start {
+		t.SessionOpen, t.SessionClose = time.Unix(r.Start, 0), time.Unix(r.End, 0)
+	}
+	return t
+}
+
+// quoteSymbol names one instrument on each quote source
+type quoteSymbol struct {
+	yahoo string // URL-escaped Yahoo ticker, e.g. "%5EGSPC"
+	stooq string // Stooq ticker, e.g. "^spx"; "" if Stooq has none
+}
+
+// quoteSource is a source of delayed quotes for indices, futures and FX
+type quoteSource interface {
+	Name() string
+	Quote(ctx context.Context, sym quoteSymbol) (quote, error)
+}
+
+// quoteSources are tried in order until one answers
+var quoteSources = []quoteSource{yahoo{}, stooq{}}
+
+// fetchQuote returns the first successful quote from quoteSources. The
+// Symbol is always the Yahoo ticker so callers see one key per instrument
+// whichever source answered.
+func fetchQuote(ctx context.Context, sym quoteSymbol) (quote, error) {
+	var errs []string
+	for _, src := range quoteSources {
+		q, err := src.Quote(ctx, sym)
+		if err == nil {
+			q.Symbol, _ = url.PathUnescape(sym.yahoo)
+			q.fetched = time.Now()
+			return q, nil
+		}
+		errs = append(errs, src.Name()+": "+err.Error())
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return quote{}, fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+// ─── Yahoo Finance chart endpoint ────────────────────────────────────────────
+// Uses the same v8/finance/chart endpoint as:
+//   curl -s -L "https://query1.finance.yahoo.com/v8/finance/chart/%5EGSPC" \
+//        -H "User-Agent: Mozilla/5.0"
+// The meta object contains regularMarketPrice, previousClose, and
+// regularMarketChangePercent — everything we need in one request.
+// query2 serves the same API and is often still open when query1 is
+// rate-limiting or showing the EU consent wall.
+
+type yahoo struct{}
+
+func (yahoo) Name() string { return "yahoo" }
+
+var yahooHosts = []string{"query1.finance.yahoo.com", "query2.finance.yahoo.com"}
+
+// yahooRetries is how many times a 429 is retried on one host, with a
+// doubling backoff starting at one second.
+const yahooRetries = 2
+
+func (yahoo) Quote(ctx context.Context, sym quoteSymbol) (quote, error) {
+	var err error
+	for _, host := range yahooHosts {
+		var q quote
+		if q, err = fetchYahooChart(ctx, host, sym.yahoo); err == nil {
+			return q, nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return quote{}, err
+}
+
+// errRateLimited is a 429; retryAfter is the server's hint, if any
+type errRateLimited struct{ retryAfter time.Duration }
+
+func (errRateLimited) Error() string { return "rate limited (HTTP 429)" }
+
+func fetchYahooChart(ctx context.Context, host, symbol string) (quote, error) {
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		q, err := fetchYahooChartOnce(ctx, host, symbol)
+		rl, limited := err.(errRateLimited)
+		if !limited || attempt >= yahooRetries {
+			return q, err
+		}
+		wait := backoff
+		if rl.retryAfter > 0 && rl.retryAfter < 5*time.Second {
+			wait = rl.retryAfter
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return quote{}, err
+		}
+		backoff *= 2
+	}
+}
+
+func fetchYahooChartOnce(ctx context.Context, host, symbol string) (quote, error) {
+	url := "https://" + host + "/v8/finance/chart/" + symbol
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return quote{}, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return quote{}, fmt.Errorf("yahoo chart request for %s: %w", symbol, err)
+	}
+	defer resp.Body.Close()
+
+	// EU visitors can get redirected to an HTML consent page instead of JSON
+	if strings.Contains(resp.Request.URL.Host, "consent.") {
+		return quote{}, fmt.Errorf("yahoo consent wall for %s", symbol)
+	}
+	if resp.StatusCode == 429 {
+		secs, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return quote{}, errRateLimited{time.Duration(secs) * time.Second}
+	}
+	if resp.StatusCode != 200 {
+		return quote{}, fmt.Errorf("yahoo chart HTTP %d for %s", resp.StatusCode, symbol)
+	}
+
+	var envelope struct {
+		Chart struct {
+			Result []struct {
+				Meta quote `json:"meta"`
+			} `json:"result"`
+			Error *struct {
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"chart"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return quote{}, fmt.Errorf("decoding yahoo chart for %s: %w", symbol, err)
+	}
+	if envelope.Chart.Error != nil {
+		return quote{}, fmt.Errorf("yahoo chart error for %s: %s", symbol, envelope.Chart.Error.Description)
+	}
+	if len(envelope.Chart.Result) == 0 {
+		return quote{}, fmt.Errorf("no results from yahoo chart for %s", symbol)
+	}
+
+	meta := envelope.Chart.Result[0].Meta
+	// Compute pct change if not provided directly
+	if meta.RegularMarketChangePercent == 0 && meta.PreviousClose != 0 {
+		meta.RegularMarketChangePercent = ((meta.RegularMarketPrice - meta.PreviousClose) / meta.PreviousClose) * 100
+	}
+	// Fallback: use chartPreviousClose if previousClose is zero
+	if meta.PreviousClose == 0 && meta.ChartPreviousClose != 0 {
+		meta.PreviousClose = meta.ChartPreviousClose
+		if meta.RegularMarketChangePercent == 0 {
+			meta.RegularMarketChangePercent = ((meta.RegularMarketPrice - meta.ChartPreviousClose) / meta.ChartPreviousClose) * 100
+		}
+	}
+
+	return meta, nil
+}
+
+// ─── Stooq ────────────────────────────────────────────────────────────────────
+// Stooq's light quote CSV needs no key or cookies:
+//   curl "https://stooq.com/q/l/?s=^spx&f=sd2t2ohlcp&h&e=csv"
+// Columns: Symbol,Date,Time,Open,High,Low,Close,Prev. Unknown symbols come
+// back as a row of N/D.
+
+type stooq struct{}
+
+func (stooq) Name() string { return "stooq" }
+
+func (stooq) Quote(ctx context.Context, sym quoteSymbol) (quote, error) {
+	if sym.stooq == "" {
+		return quote{}, fmt.Errorf("no stooq symbol")
+	}
+	endpoint := "https://stooq.com/q/l/?s=" + url.QueryEscape(sym.stooq) + "&f=sd2t2ohlcp&h&e=csv"
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return quote{}, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return quote{}, fmt.Errorf("stooq request for %s: %w", sym.stooq, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return quote{}, fmt.Errorf("stooq HTTP %d for %s", resp.StatusCode, sym.stooq)
+	}
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		return quote{}, fmt.Errorf("decoding stooq quote for %s: %w", sym.stooq, err)
+	}
+	if len(rows) < 2 || len(rows[1]) < 8 {
+		return quote{}, fmt.Errorf("no stooq quote for %s", sym.stooq)
+	}
+	price, err1 := strconv.ParseFloat(rows[1][6], 64)
+	prev, err2 := strconv.ParseFloat(rows[1][7], 64)
+	if err1 != nil || err2 != nil || price <= 0 {
+		return quote{}, fmt.Errorf("no stooq quote for %s", sym.stooq)
+	}
+
+	q := quote{RegularMarketPrice: price, PreviousClose: prev}
+	if prev != 0 {
+		q.RegularMarketChangePercent = (price - prev) / prev * 100
+	}
+	return q, nil
+}
+
+// YahooURL returns the Yahoo Finance page of a Yahoo ticker such as "^GSPC"
+// or "GC=F". URL-escaped tickers ("%5EGSPC", "CL%3DF") are decoded first so
+// they aren't escaped twice.
+func YahooURL(symbol string) string {
+	if s, err := url.PathUnescape(symbol); err == nil {
+		symbol = s
+	}
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return ""
+	}
+	return "https://finance.yahoo.com/quote/" + url.PathEscape(symbol) + "/"
+}
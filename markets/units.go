@@ -0,0 +1,100 @@
+package markets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CommodityOptions controls how commodity prices are presented. The zero
+// value keeps Yahoo's native quotes: USD in US trade units.
+type CommodityOptions struct {
+	Currency string // ISO code, e.g. "EUR"; "" or "USD" = no conversion
+	Units    string // "us" (bbl, troy oz, lb) or "metric" (bbl, g, kg)
+}
+
+// metricUnits maps a commodity's US trade unit to its metric equivalent and
+// how many metric units fit in one US unit. Crude stays per barrel — it is
+// quoted that way everywhere.
+var metricUnits = map[string]struct {
+	unit   string
+	factor float64
+}{
+	"oz": {"g", 31.1034768},  // troy ounce
+	"lb": {"kg", 0.45359237}, // avoirdupois pound
+}
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"INR": "₹",
+	"KRW": "₩",
+	"CHF": "CHF ",
+}
+
+// CurrencySymbol returns the display prefix for an ISO currency code
+func CurrencySymbol(code string) string {
+	code = strings.ToUpper(code)
+	if code == "" {
+		return "$"
+	}
+	if s, ok := currencySymbols[code]; ok {
+		return s
+	}
+	return code + " "
+}
+
+// fetchUSDRate returns how many units of currency one US dollar buys,
+// using Yahoo's "<CUR>=X" FX tickers.
+func fetchUSDRate(ctx context.Context, currency string) (float64, error) {
+	meta, err := fetchQuote(ctx, quoteSymbol{currency + "%3DX", "usd" + strings.ToLower(currency)})
+	if err != nil {
+		return 0, fmt.Errorf("USD→%s rate: %w", currency, err)
+	}
+	if meta.RegularMarketPrice <= 0 {
+		return 0, fmt.Errorf("USD→%s rate: no price", currency)
+	}
+	return meta.RegularMarketPrice, nil
+}
+
+// convertCommodities rewrites prices and unit labels according to opts.
+// The percentage change is unit- and currency-independent, so it is kept.
+// If the FX rate can't be fetched the prices stay in USD and the error is
+// returned so the caller can surface it.
+func convertCommodities(ctx context.Context, comms []Commodity, opts CommodityOptions) ([]Commodity, error) {
+	currency := strings.ToUpper(opts.Currency)
+	if currency == "" {
+		currency = "USD"
+	}
+
+	rate := 1.0
+	var fxErr error
+	if currency != "USD" {
+		r, err := fetchUSDRate(ctx, currency)
+		if err != nil {
+			currency, fxErr = "USD", err
+		} else {
+			rate = r
+		}
+	}
+
+	for i := range comms {
+		c := &comms[i]
+		factor := rate
+		unit := c.Unit
+		if opts.Units == "metric" {
+			if mu, ok := metricUnits[unit]; ok {
+				factor /= mu.factor
+				unit = mu.unit
+			}
+		}
+		c.Price *= factor
+		c.PrevClose *= factor
+		c.Currency = currency
+		c.Unit = unit
+	}
+	return comms, fxErr
+}
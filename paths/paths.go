@@ -0,0 +1,34 @@
+// Package paths decides where watchtower keeps its caches and histories:
+// ~/.cache/watchtower by default, data_dir when the config sets one, and a
+// scratch directory in fixture mode.
+package paths
+
+import (
+	"os"
+	"path/filepath"
+
+	"watchtower/fixtures"
+)
+
+// dataDir replaces ~/.cache/watchtower when set; see SetDataDir
+var dataDir string
+
+// SetDataDir moves caches and histories to dir (data_dir in the config);
+// "" restores the default. config.Load calls it, so a command has to load
+// the config before it reads or writes anything under CacheDir.
+func SetDataDir(dir string) {
+	dataDir = dir
+}
+
+// CacheDir returns the directory for caches and histories under home:
+// data_dir if set, else ~/.cache/watchtower, and a scratch directory in
+// fixture mode so canned briefs and prices never land in the real caches.
+func CacheDir(home string) string {
+	if fixtures.Dir() != "" {
+		return filepath.Join(os.TempDir(), "watchtower-fixtures")
+	}
+	if dataDir != "" {
+		return dataDir
+	}
+	return filepath.Join(home, ".cache", "watchtower")
+}
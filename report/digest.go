@@ -0,0 +1,150 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"watchtower/feeds"
+	"watchtower/intel"
+)
+
+// Digest is a snapshot plus the headlines behind it, rendered for people
+// who read watchtower by mail instead of keeping the dashboard open.
+type Digest struct {
+	Snapshot
+	Headlines []feeds.NewsItem
+}
+
+// TopHeadlines returns up to n high and critical items, most severe first,
+// keeping the feed order within a level.
+func TopHeadlines(items []feeds.NewsItem, n int) []feeds.NewsItem {
+	var out []feeds.NewsItem
+	for _, level := range []feeds.ThreatLevel{feeds.ThreatCritical, feeds.ThreatHigh} {
+		for _, it := range items {
+			if len(out) >= n {
+				return out
+			}
+			if it.ThreatLevel == level {
+				out = append(out, it)
+			}
+		}
+	}
+	return out
+}
+
+// DigestMarkdown renders the digest as Markdown: top headlines, the brief
+// and the market movers.
+func DigestMarkdown(d Digest) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Watchtower digest — %s\n\n", d.TakenAt.Format("2006-01-02 15:04 MST")))
+
+	if len(d.Headlines) > 0 {
+		sb.WriteString("## Top headlines\n\n")
+		for _, it := range d.Headlines {
+			title := it.Title
+			if it.URL != "" {
+				title = fmt.Sprintf("[%s](%s)", it.Title, it.URL)
+			}
+			sb.WriteString(fmt.Sprintf("- **%s** %s — %s\n", it.ThreatLevel, title, it.Source))
+		}
+		sb.WriteString("\n")
+	}
+
+	if d.Brief != nil {
+		sb.WriteString(BriefMarkdown(d.Brief))
+	}
+	sb.WriteString(moversMarkdown(d.Snapshot))
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// digestTemplate keeps its styling inline, since most mail clients drop
+// <style> blocks.
+var digestTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Watchtower digest — {{.Taken}}</title></head>
+<body style="font-family: sans-serif; max-width: 720px; margin: 0 auto; color: #24292f;">
+<h1 style="font-size: 20px;">Watchtower digest — {{.Taken}}</h1>
+{{- if .Headlines}}
+<h2 style="font-size: 16px;">Top headlines</h2>
+<ul>
+{{- range .Headlines}}
+<li><strong style="color: {{.Color}};">{{.Level}}</strong> {{if .URL}}<a href="{{.URL}}">{{.Title}}</a>{{else}}{{.Title}}{{end}} — {{.Source}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- with .Brief}}
+<h2 style="font-size: 16px;">Intel brief</h2>
+<p style="color: #57606a;"><em>{{.Model}} · {{.GeneratedAt.Format "Jan 02 15:04"}}</em></p>
+<p>{{.Summary}}</p>
+{{- if .KeyThreats}}
+<h3 style="font-size: 14px;">Key threats</h3>
+<ul>
+{{- range .KeyThreats}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .CountryRisks}}
+<h3 style="font-size: 14px;">Country risk index</h3>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr><th align="left">Country</th><th align="right">Score</th><th align="left">Reason</th></tr>
+{{- range .CountryRisks}}
+<tr><td>{{.Country}}</td><td align="right">{{.Score}}</td><td>{{.Reason}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+{{- if .Movers}}
+<h2 style="font-size: 16px;">Market movers</h2>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr><th align="left">Asset</th><th align="right">Price</th><th align="right">Change</th></tr>
+{{- range .Movers}}
+<tr><td>{{.Name}}</td><td align="right">{{.Price}}</td><td align="right" style="color: {{.Color}};">{{.Change}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+type htmlHeadline struct {
+	Level, Color, Title, URL, Source string
+}
+
+type htmlMover struct {
+	Name, Price, Change, Color string
+}
+
+// DigestHTML renders the digest as a self-contained HTML page suitable
+// for piping into a mail tool.
+func DigestHTML(d Digest) (string, error) {
+	data := struct {
+		Taken     string
+		Headlines []htmlHeadline
+		Brief     *intel.Brief
+		Movers    []htmlMover
+	}{Taken: d.TakenAt.Format("2006-01-02 15:04 MST"), Brief: d.Brief}
+
+	for _, it := range d.Headlines {
+		color := "#bc4c00"
+		if it.ThreatLevel == feeds.ThreatCritical {
+			color = "#cf222e"
+		}
+		data.Headlines = append(data.Headlines, htmlHeadline{it.ThreatLevel.String(), color, it.Title, it.URL, it.Source})
+	}
+	for _, mv := range topMovers(d.Snapshot, 5) {
+		color := "#1a7f37"
+		if mv.changePct < 0 {
+			color = "#cf222e"
+		}
+		data.Movers = append(data.Movers, htmlMover{mv.name, mv.priceText(), fmt.Sprintf("%+.2f%%", mv.changePct), color})
+	}
+
+	var sb strings.Builder
+	if err := digestTemplate.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("rendering digest: %w", err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,170 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"watchtower/intel"
+	"watchtower/markets"
+	"watchtower/weather"
+)
+
+// Snapshot is the dashboard data rendered into a plain-text report.
+// Any field may be empty; missing sections are simply skipped.
+type Snapshot struct {
+	TakenAt     time.Time
+	City        string
+	TempUnit    string // "celsius" or "fahrenheit"
+	WindUnit    string // "kmh", "mph", "ms" or "knots"
+	Brief       *intel.Brief
+	Crypto      []markets.CryptoPrice
+	Indices     []markets.StockIndex
+	Commodities []markets.Commodity
+	Weather     *weather.Conditions
+	Forecast    []weather.DayForecast
+}
+
+// mover is one row of the market movers table, normalised across asset classes
+type mover struct {
+	name      string
+	price     float64
+	currency  string // "" for index levels, which are points
+	changePct float64
+}
+
+// priceText formats the mover's price, without a symbol for index levels
+func (mv mover) priceText() string {
+	if mv.currency == "" {
+		return markets.FormatLevel(mv.price)
+	}
+	return markets.FormatPriceIn(mv.price, mv.currency)
+}
+
+// BriefMarkdown renders just the intel brief — summary, key threats and
+// country risks — for pasting on its own.
+func BriefMarkdown(b *intel.Brief) string {
+	var sb strings.Builder
+	sb.WriteString("## Intel brief\n\n")
+	sb.WriteString(fmt.Sprintf("_%s · %s_\n\n", b.Model, b.GeneratedAt.Format("Jan 02 15:04")))
+	sb.WriteString(b.Summary + "\n\n")
+
+	if len(b.KeyThreats) > 0 {
+		sb.WriteString("### Key threats\n\n")
+		for _, t := range b.KeyThreats {
+			sb.WriteString("- " + t + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(b.CountryRisks) > 0 {
+		sb.WriteString("### Country risk index\n\n")
+		sb.WriteString("| Country | Score | Reason |\n|---|---:|---|\n")
+		for _, cr := range b.CountryRisks {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %s |\n", cr.Country, cr.Score, cr.Reason))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Markdown renders the snapshot as clean Markdown, distinct from the
+// lipgloss renderers so it pastes well into notes and messages.
+func Markdown(s Snapshot) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Watchtower snapshot — %s\n\n", s.TakenAt.Format("2006-01-02 15:04 MST")))
+
+	if s.Brief != nil {
+		sb.WriteString(BriefMarkdown(s.Brief))
+	}
+
+	sb.WriteString(moversMarkdown(s))
+
+	if wc := s.Weather; wc != nil {
+		sb.WriteString(fmt.Sprintf("## Weather — %s\n\n", s.City))
+		sb.WriteString(fmt.Sprintf("%s, %s (feels like %s), humidity %d%%, wind %s %s, UV %.0f\n\n",
+			wc.Description, formatTemp(wc.TempC, s.TempUnit), formatTemp(wc.FeelsLikeC, s.TempUnit),
+			wc.Humidity, weather.FormatWind(wc.WindSpeedKmh, s.WindUnit), weather.WindDirectionStr(wc.WindDirection), wc.UVIndex))
+		for i, f := range s.Forecast {
+			if i >= 5 {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- %s: %s, %s / %s, %.1fmm\n",
+				f.Date.Format("Mon Jan 02"), f.Desc,
+				formatTemp(f.MaxTempC, s.TempUnit), formatTemp(f.MinTempC, s.TempUnit), f.RainMM))
+		}
+		if len(s.Forecast) > 0 {
+			sb.WriteString("\n")
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// moversMarkdown renders the market movers table, or "" without prices
+func moversMarkdown(s Snapshot) string {
+	movers := topMovers(s, 5)
+	if len(movers) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("## Market movers\n\n")
+	sb.WriteString("| Asset | Price | Change |\n|---|---:|---:|\n")
+	for _, mv := range movers {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %+.2f%% |\n", mv.name, mv.priceText(), mv.changePct))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// TopMover is the asset with the largest absolute change, if any prices
+// were fetched.
+func (s Snapshot) TopMover() (name string, changePct float64, ok bool) {
+	movers := topMovers(s, 1)
+	if len(movers) == 0 {
+		return "", 0, false
+	}
+	return movers[0].name, movers[0].changePct, true
+}
+
+// topMovers returns up to n assets with the largest absolute change
+func topMovers(s Snapshot, n int) []mover {
+	var all []mover
+	for _, p := range s.Crypto {
+		all = append(all, mover{p.Name, p.PriceUSD, "USD", p.Change24h})
+	}
+	for _, idx := range s.Indices {
+		all = append(all, mover{idx.Name, idx.Price, "", idx.ChangePct})
+	}
+	for _, c := range s.Commodities {
+		all = append(all, mover{c.Name, c.Price, c.Currency, c.ChangePct})
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return math.Abs(all[i].changePct) > math.Abs(all[j].changePct)
+	})
+	if len(all) > n {
+		all = all[:n]
+	}
+	return all
+}
+
+func formatTemp(celsius float64, unit string) string {
+	if unit == "fahrenheit" {
+		return fmt.Sprintf("%.1f°F", celsius*9/5+32)
+	}
+	return fmt.Sprintf("%.1f°C", celsius)
+}
+
+// WriteFile writes the report into dir as watchtower-snapshot-<timestamp>.md
+// and returns the path written.
+func WriteFile(dir, md string, at time.Time) (string, error) {
+	path := filepath.Join(dir, "watchtower-snapshot-"+at.Format("20060102-150405")+".md")
+	if err := os.WriteFile(path, []byte(md), 0644); err != nil {
+		return "", fmt.Errorf("writing snapshot: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+	"watchtower/config"
+)
+
+// Terminal focus reporting (DECSET 1004). Once enabled the terminal sends
+// CSI I when its window gains focus and CSI O when it loses it. Terminals
+// without support ignore the request, so the dashboard simply never sees
+// a focus event and keeps relying on the refresh tick.
+const (
+	focusReportingOn  = "\x1b[?1004h"
+	focusReportingOff = "\x1b[?1004l"
+)
+
+// focusMsg is sent when the terminal window regains focus
+type focusMsg struct{}
+
+// FocusReporting returns the escape sequences that enable and disable focus
+// events, or empty strings when refresh_on_focus_seconds is off.
+func FocusReporting(cfg *config.Config) (enable, disable string) {
+	if cfg.FocusRefreshSec <= 0 {
+		return "", ""
+	}
+	return focusReportingOn, focusReportingOff
+}
+
+// isFocusIn reports whether msg is the focus-in sequence. This bubbletea
+// version has no focus events of its own; it delivers CSI I as an
+// unrecognised sequence whose String form is "?CSI[73]?".
+func isFocusIn(msg interface{}) bool {
+	s, ok := msg.(fmt.Stringer)
+	return ok && s.String() == "?CSI[73]?"
+}
+
+// shouldRefreshOnFocus reports whether regaining focus should trigger a
+// refresh: the feature is on, no refresh is running, and the last news or
+// market data landed more than refresh_on_focus_seconds ago. lastRefresh
+// can't be used: it tracks the brief and is cleared by every news tick.
+func (m Model) shouldRefreshOnFocus() bool {
+	if m.cfg.FocusRefreshSec <= 0 || m.lastData.IsZero() {
+		return false
+	}
+	if done, total := m.refreshProgress(); done < total {
+		return false
+	}
+	return time.Since(m.lastData) > time.Duration(m.cfg.FocusRefreshSec)*time.Second
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"watchtower/feeds"
+)
+
+// TestShouldRefreshOnFocus checks focus refresh keeps working after news
+// ticks have cleared lastRefresh, and without an LLM key to set it at all
+func TestShouldRefreshOnFocus(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.FocusRefreshSec = 60
+	cfg.LLMAPIKey = ""
+
+	m := NewModel(cfg)
+	m.loading = map[string]bool{}
+	if m.shouldRefreshOnFocus() {
+		t.Error("refreshed on focus before any data landed")
+	}
+
+	mm, _ := m.Update(globalNewsMsg{items: []feeds.NewsItem{{Title: "Story", Published: time.Now()}}})
+	m = mm.(Model)
+	m.loading = map[string]bool{}
+	m.lastRefresh = time.Time{} // as a news tick leaves it
+	if m.shouldRefreshOnFocus() {
+		t.Error("refreshed on focus with fresh data")
+	}
+
+	m.lastData = time.Now().Add(-2 * time.Minute)
+	if !m.shouldRefreshOnFocus() {
+		t.Error("stale data after a news tick should refresh on focus")
+	}
+
+	m.loading["global"] = true
+	if m.shouldRefreshOnFocus() {
+		t.Error("refreshed on focus while a refresh is running")
+	}
+
+	cfg.FocusRefreshSec = 0
+	delete(m.loading, "global")
+	if m.shouldRefreshOnFocus() {
+		t.Error("refreshed on focus with the feature off")
+	}
+}
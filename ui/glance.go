@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"watchtower/feeds"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The glance view (w, or glance_view: true) replaces the whole dashboard
+// with a few lines for a small tmux pane or a wall display: the threat
+// gauge, the critical count, the riskiest country and the biggest market
+// move.
+
+// threatWeights is each level's contribution to the threat gauge
+var threatWeights = map[feeds.ThreatLevel]int{
+	feeds.ThreatCritical: 100,
+	feeds.ThreatHigh:     70,
+	feeds.ThreatMedium:   40,
+	feeds.ThreatLow:      15,
+}
+
+// threatGauge rates the news 0–100 as the mean weight of its items, so a
+// day of mostly critical headlines reads high and a quiet one low
+func threatGauge(items []feeds.NewsItem) int {
+	if len(items) == 0 {
+		return 0
+	}
+	total := 0
+	for _, item := range items {
+		total += threatWeights[item.ThreatLevel]
+	}
+	return total / len(items)
+}
+
+// gaugeLabel names a threat gauge reading and the style it's drawn in
+func gaugeLabel(score int) (string, lipgloss.Style) {
+	switch {
+	case score >= 75:
+		return "SEVERE", StyleCritical
+	case score >= 50:
+		return "HIGH", StyleHighThreat
+	case score >= 25:
+		return "ELEVATED", StyleMediumThreat
+	default:
+		return "LOW", StyleLowThreat
+	}
+}
+
+// renderGlance draws the glance view, centered in the terminal
+func (m Model) renderGlance() string {
+	const labelW = 14
+	w := m.termWidth - 4
+	line := func(label, value string) string {
+		return StyleMuted.Render(fmt.Sprintf("%-*s", labelW, label)) + value
+	}
+
+	var lines []string
+	title := StyleTitle.Render("🌍 WATCHTOWER")
+	if len(m.loading) > 0 {
+		title += " " + m.spinner.View()
+	} else if !m.lastRefresh.IsZero() {
+		title += StyleMuted.Render("  ↻ " + m.lastRefresh.Format("15:04"))
+	}
+	lines = append(lines, title, "")
+
+	items := m.newsItems()
+	if len(items) == 0 {
+		lines = append(lines, line("THREAT", StyleMuted.Render("waiting for news…")))
+	} else {
+		score := threatGauge(items)
+		label, style := gaugeLabel(score)
+		const barW = 20
+		filled := score * barW / 100
+		bar := style.Render(strings.Repeat("█", filled)) + StyleMuted.Render(strings.Repeat("░", barW-filled))
+		lines = append(lines, line("THREAT", fmt.Sprintf("%s %3d %s", bar, score, style.Render(" "+label+" "))))
+
+		critical, first := 0, ""
+		for _, item := range items {
+			if item.ThreatLevel == feeds.ThreatCritical {
+				if critical == 0 {
+					first = item.Title
+				}
+				critical++
+			}
+		}
+		count := StyleMuted.Render("none")
+		if critical > 0 {
+			count = StyleNegative.Render(fmt.Sprintf("%d", critical)) +
+				StyleMuted.Render(" · "+truncate(first, max(w-labelW-6, 10)))
+		}
+		lines = append(lines, line("CRITICAL", count))
+	}
+
+	if m.brief != nil && len(m.brief.CountryRisks) > 0 {
+		top := m.brief.CountryRisks[0]
+		for _, cr := range m.brief.CountryRisks[1:] {
+			if cr.Score > top.Score {
+				top = cr
+			}
+		}
+		lines = append(lines, line("HIGHEST RISK", fmt.Sprintf("%s %s", top.Country, StyleBriefMeta.Render(fmt.Sprintf("%d", top.Score)))))
+	}
+
+	if name, pct, ok := m.snapshot().TopMover(); ok {
+		lines = append(lines, line("TOP MOVER", name+" "+m.changeStyle(pct).Render(fmt.Sprintf("%+.2f%%", pct))))
+	}
+
+	lines = append(lines, "", StyleMuted.Render("w full dashboard  r refresh  q quit"))
+	// Join first so the lines stay left-aligned within the centered block
+	block := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.Place(m.termWidth, m.height, lipgloss.Center, lipgloss.Center, block)
+}
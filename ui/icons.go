@@ -0,0 +1,48 @@
+package ui
+
+// asciiIcons maps the emoji watchtower renders to fixed-width ASCII for
+// ascii_icons mode. Emoji width varies between terminals (1 or 2 cells) and
+// some fonts draw them as tofu, which breaks table alignment.
+var asciiIcons = map[string]string{
+	// Weather conditions (weather.wmoCodeToEmoji)
+	"☀️": "CLR",
+	"🌙":  "CLR",
+	"🌤️": "MCL",
+	"⛅":  "PCL",
+	"☁️": "OVC",
+	"🌫️": "FOG",
+	"🌦️": "SHW",
+	"🌧️": "RN ",
+	"❄️": "SN ",
+	"⛈️": "TS ",
+	"🌡️": "?  ",
+
+	// Weather details
+	"💧":    "Hum",
+	"💨":    "Wind",
+	"☀ UV": "UV",
+	"🌅":    "Rise",
+	"🌇":    "Set",
+
+	// News markers
+	"📌": "*",
+	"🔴": "!!",
+}
+
+// icon returns emoji as is, or its ASCII stand-in in ascii_icons mode.
+// Weather condition codes are all 3 columns wide so tables line up.
+func (m Model) icon(emoji string) string {
+	if a, ok := asciiIcons[emoji]; ok && m.cfg.ASCIIIcons {
+		return a
+	}
+	return emoji
+}
+
+// glyph returns a purely decorative emoji followed by a space, or nothing
+// in ascii_icons mode where the text beside it already says enough.
+func (m Model) glyph(emoji string) string {
+	if m.cfg.ASCIIIcons {
+		return ""
+	}
+	return emoji + " "
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// translations maps a `language` code to translated UI strings, keyed by
+// the English text. Missing entries fall back to English, so a language
+// can be added a few strings at a time. Covered so far: weather
+// descriptions and threat level labels (kept to 8 columns for the badge).
+var translations = map[string]map[string]string{
+	"es": {
+		"Clear sky":              "Despejado",
+		"Clear night":            "Noche despejada",
+		"Mainly clear":           "Mayormente despejado",
+		"Partly cloudy":          "Parcialmente nublado",
+		"Overcast":               "Cubierto",
+		"Fog":                    "Niebla",
+		"Drizzle":                "Llovizna",
+		"Rain":                   "Lluvia",
+		"Snow":                   "Nieve",
+		"Rain showers":           "Chubascos",
+		"Thunderstorm":           "Tormenta",
+		"Thunderstorm with hail": "Tormenta con granizo",
+		"Unknown":                "Desconocido",
+
+		"CRITICAL": "CRÍTICO",
+		"HIGH":     "ALTO",
+		"MEDIUM":   "MEDIO",
+		"LOW":      "BAJO",
+		"INFO":     "INFO",
+	},
+	"de": {
+		"Clear sky":              "Klarer Himmel",
+		"Clear night":            "Klare Nacht",
+		"Mainly clear":           "Überwiegend klar",
+		"Partly cloudy":          "Teilweise bewölkt",
+		"Overcast":               "Bedeckt",
+		"Fog":                    "Nebel",
+		"Drizzle":                "Nieselregen",
+		"Rain":                   "Regen",
+		"Snow":                   "Schnee",
+		"Rain showers":           "Regenschauer",
+		"Thunderstorm":           "Gewitter",
+		"Thunderstorm with hail": "Gewitter mit Hagel",
+		"Unknown":                "Unbekannt",
+
+		"CRITICAL": "KRITISCH",
+		"HIGH":     "HOCH",
+		"MEDIUM":   "MITTEL",
+		"LOW":      "NIEDRIG",
+		"INFO":     "INFO",
+	},
+	"pt": {
+		"Clear sky":              "Céu limpo",
+		"Clear night":            "Noite limpa",
+		"Mainly clear":           "Pouco nublado",
+		"Partly cloudy":          "Parcialmente nublado",
+		"Overcast":               "Encoberto",
+		"Fog":                    "Nevoeiro",
+		"Drizzle":                "Chuvisco",
+		"Rain":                   "Chuva",
+		"Snow":                   "Neve",
+		"Rain showers":           "Aguaceiros",
+		"Thunderstorm":           "Trovoada",
+		"Thunderstorm with hail": "Trovoada com granizo",
+		"Unknown":                "Desconhecido",
+
+		"CRITICAL": "CRÍTICO",
+		"HIGH":     "ALTO",
+		"MEDIUM":   "MÉDIO",
+		"LOW":      "BAIXO",
+		"INFO":     "INFO",
+	},
+}
+
+// LanguageSupported reports whether lang is English or has a translation table
+func LanguageSupported(lang string) bool {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	_, ok := translations[lang]
+	return ok || lang == "" || lang == "en"
+}
+
+// tr translates an English UI string into the configured language
+func (m Model) tr(s string) string {
+	if t, ok := translations[strings.ToLower(m.cfg.Language)][s]; ok {
+		return t
+	}
+	return s
+}
+
+// padRight pads s with spaces to width display columns; unlike %-*s it
+// counts accented letters as one column.
+func padRight(s string, width int) string {
+	if gap := width - lipgloss.Width(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
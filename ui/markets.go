@@ -0,0 +1,437 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"watchtower/markets"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Markets tab ──────────────────────────────────────────────────────────────
+
+// renderMarketsContent renders the full-width Markets tab: one section per
+// asset class with the columns the overview quadrant has no room for.
+func (m Model) renderMarketsContent() (string, int) {
+	if m.width == 0 {
+		return "", 0
+	}
+	w := m.width - 6
+	var sb strings.Builder
+
+	sb.WriteString(StyleSectionHeader.Render(" ₿ CRYPTO") + m.loadingMark("crypto") + dataAge(m.cryptoAges()...) + "\n\n")
+	sb.WriteString(m.renderCryptoTable(w))
+	sb.WriteString("\n")
+
+	// First row line of each selectable table: past its header and divider
+	rowStart := func() int { return strings.Count(sb.String(), "\n") + 2 }
+
+	sb.WriteString(StyleSectionHeader.Render(" 📈 INDICES") + m.loadingMark("stocks") + dataAge(quoteAges(m.quoteTimes("stocks"))...) + "\n\n")
+	indexStart := rowStart()
+	sb.WriteString(m.renderIndexTable(w))
+	sb.WriteString("\n")
+
+	sb.WriteString(StyleSectionHeader.Render(" 🛢  COMMODITIES") + m.loadingMark("commodities") + dataAge(quoteAges(m.quoteTimes("commodities"))...) + "\n\n")
+	commodityStart := rowStart()
+	sb.WriteString(m.renderCommodityTable(w))
+	sb.WriteString("\n")
+
+	sb.WriteString(StyleSectionHeader.Render(" 📊 PREDICTION MARKETS") + m.loadingMark("poly") + "\n\n")
+	polyStart := rowStart()
+	sb.WriteString(m.renderPolyTable(w))
+
+	// The rows sit in three tables, so report the header height that puts
+	// the selected row at the right line for scrolling
+	nIdx, nQuotes := len(m.shownIndices()), len(m.quoteRows())
+	hdrLines := polyStart - nQuotes
+	switch sel := m.selectedMarketIdx; {
+	case sel < nIdx:
+		hdrLines = indexStart
+	case sel < nQuotes:
+		hdrLines = commodityStart - nIdx
+	}
+	return sb.String(), hdrLines
+}
+
+// marketQuote is a selectable index or commodity row on the Markets tab
+type marketQuote struct {
+	symbol string // Yahoo ticker, e.g. "^GSPC"
+	name   string
+}
+
+// shownIndices are the index rows on screen: none while the section
+// shows an error instead of its table
+func (m Model) shownIndices() []markets.StockIndex {
+	if _, failed := m.errors["stocks"]; failed {
+		return nil
+	}
+	return m.stockIndices
+}
+
+// shownCommodities is shownIndices for the commodities table
+func (m Model) shownCommodities() []markets.Commodity {
+	if _, failed := m.errors["commodities"]; failed {
+		return nil
+	}
+	return m.commodities
+}
+
+// quoteRows lists the selectable index and commodity rows in screen order
+func (m Model) quoteRows() []marketQuote {
+	var rows []marketQuote
+	for _, idx := range m.shownIndices() {
+		rows = append(rows, marketQuote{idx.Symbol, idx.Name})
+	}
+	for _, c := range m.shownCommodities() {
+		rows = append(rows, marketQuote{c.Symbol, c.Name})
+	}
+	return rows
+}
+
+// selectMark is the row prefix marking the Markets tab selection
+func selectMark(selected bool) string {
+	if selected {
+		return StyleSelectedTitle.Render("▶ ")
+	}
+	return "  "
+}
+
+// changeStr renders a percentage change with a direction arrow and color
+func (m Model) changeStr(pct float64) string {
+	icon := "▲"
+	if pct < 0 {
+		icon = "▼"
+	}
+	return m.changeStyle(pct).Render(fmt.Sprintf("%s%6.2f%%", icon, pct))
+}
+
+// changeStyle colors a percentage move: muted below market_change_threshold,
+// green/red above it, and bold beyond market_change_strong (if set).
+func (m Model) changeStyle(pct float64) lipgloss.Style {
+	abs := math.Abs(pct)
+	switch {
+	case abs < m.cfg.ChangeThreshold:
+		return StyleNeutral
+	case m.cfg.ChangeStrong > 0 && abs >= m.cfg.ChangeStrong && pct < 0:
+		return StyleNegativeStrong
+	case m.cfg.ChangeStrong > 0 && abs >= m.cfg.ChangeStrong:
+		return StylePositiveStrong
+	case pct < 0:
+		return StyleNegative
+	default:
+		return StylePositive
+	}
+}
+
+// staleAfter is market_stale_minutes as a duration; 0 when disabled
+func (m Model) staleAfter() time.Duration {
+	if m.cfg.MarketStaleMins <= 0 {
+		return 0
+	}
+	return time.Duration(m.cfg.MarketStaleMins) * time.Minute
+}
+
+// staleMark flags a row whose price is older than expected
+func staleMark(stale bool) string {
+	if !stale {
+		return ""
+	}
+	return StyleWarning.Render(" stale")
+}
+
+// cryptoAges lists how old each coin's price is
+func (m Model) cryptoAges() []time.Duration {
+	var ages []time.Duration
+	now := time.Now()
+	for _, p := range m.cryptoPrices {
+		if !p.LastUpdated.IsZero() {
+			ages = append(ages, now.Sub(p.LastUpdated))
+		}
+	}
+	return ages
+}
+
+// quoteTimes returns the quote times of the "stocks" or "commodities" section
+func (m Model) quoteTimes(section string) []markets.QuoteTime {
+	var ts []markets.QuoteTime
+	switch section {
+	case "stocks":
+		for _, idx := range m.stockIndices {
+			ts = append(ts, idx.QuoteTime)
+		}
+	case "commodities":
+		for _, c := range m.commodities {
+			ts = append(ts, c.QuoteTime)
+		}
+	}
+	return ts
+}
+
+// quoteAges lists how old each quote is
+func quoteAges(ts []markets.QuoteTime) []time.Duration {
+	var ages []time.Duration
+	now := time.Now()
+	for _, t := range ts {
+		if a := t.Age(now); a > 0 {
+			ages = append(ages, a)
+		}
+	}
+	return ages
+}
+
+// anyStale reports whether any of the quotes is stale
+func anyStale(ts []markets.QuoteTime, maxAge time.Duration) bool {
+	now := time.Now()
+	for _, t := range ts {
+		if t.Stale(now, maxAge) {
+			return true
+		}
+	}
+	return false
+}
+
+// dataAge renders a muted "updated 5m ago" for the oldest price in a
+// section, or "" when the source gave no times
+func dataAge(ages ...time.Duration) string {
+	var oldest time.Duration
+	for _, a := range ages {
+		oldest = max(oldest, a)
+	}
+	if oldest <= 0 {
+		return ""
+	}
+	return StyleMuted.Render("  updated " + formatAge(time.Now().Add(-oldest)))
+}
+
+// sectionStatus returns the error or loading line for a section, or "" if
+// it has data to show.
+func (m Model) sectionStatus(key string, empty bool) string {
+	if errMsg, ok := m.errors[key]; ok {
+		return StyleError.Render("  ⚠ "+errMsg) + "\n"
+	}
+	if empty {
+		return StyleMuted.Render("  "+m.spinner.View()+" fetching...") + "\n"
+	}
+	return ""
+}
+
+func (m Model) renderCryptoTable(w int) string {
+	if s := m.sectionStatus("crypto", len(m.cryptoPrices) == 0); s != "" {
+		return s
+	}
+	var sb strings.Builder
+	trendW := lipgloss.Width(m.trendHeader())
+	nameW := clampInt(w-60-trendW, 8, 24)
+	sb.WriteString(StyleTableHeader.Render(fmt.Sprintf("  %-6s %-*s %13s %9s %12s %12s",
+		"SYM", nameW, "NAME", "PRICE", "24H", "MKT CAP", "VOLUME 24H")+m.trendHeader()) + "\n")
+	sb.WriteString(StyleDivider.Render("  "+strings.Repeat("─", minInt(w-2, nameW+60+trendW))) + "\n")
+	now := time.Now()
+	for _, p := range m.cryptoPrices {
+		sb.WriteString(fmt.Sprintf("  %s %-*s %13s %s %12s %12s%s%s\n",
+			StyleSymbol.Render(fmt.Sprintf("%-6s", p.Symbol)),
+			nameW, truncate(p.Name, nameW),
+			markets.FormatPrice(p.PriceUSD),
+			m.changeStr(p.Change24h),
+			StyleMktCap.Render(fmt.Sprintf("%12s", markets.FormatLargeNum(p.MarketCapUSD))),
+			StyleMktCap.Render(fmt.Sprintf("%12s", markets.FormatLargeNum(p.Volume24hUSD))),
+			m.trendCell(cryptoHistoryKey(p)),
+			staleMark(markets.CryptoStale(p, now, m.staleAfter())),
+		))
+	}
+	return sb.String()
+}
+
+func (m Model) renderIndexTable(w int) string {
+	if s := m.sectionStatus("stocks", len(m.stockIndices) == 0); s != "" {
+		return s
+	}
+	var sb strings.Builder
+	trendW := lipgloss.Width(m.trendHeader())
+	nameW := clampInt(w-40-trendW, 10, 24)
+	sb.WriteString(StyleTableHeader.Render(fmt.Sprintf("  %-*s %13s %13s %9s",
+		nameW, "INDEX", "PRICE", "PREV CLOSE", "CHANGE")+m.trendHeader()) + "\n")
+	sb.WriteString(StyleDivider.Render("  "+strings.Repeat("─", minInt(w-2, nameW+38+trendW))) + "\n")
+	now := time.Now()
+	for i, idx := range m.stockIndices {
+		sb.WriteString(fmt.Sprintf("%s%-*s %13s %13s %s%s%s\n",
+			selectMark(m.activeTab == TabMarkets && i == m.selectedMarketIdx), nameW, truncate(idx.Name, nameW),
+			markets.FormatLevel(idx.Price),
+			StyleMuted.Render(fmt.Sprintf("%13s", markets.FormatLevel(idx.PrevClose))),
+			m.changeStr(idx.ChangePct),
+			m.trendCell(indexHistoryKey(idx)),
+			staleMark(idx.Stale(now, m.staleAfter())),
+		))
+	}
+	return sb.String()
+}
+
+func (m Model) renderCommodityTable(w int) string {
+	if s := m.sectionStatus("commodities", len(m.commodities) == 0); s != "" {
+		return s
+	}
+	var sb strings.Builder
+	trendW := lipgloss.Width(m.trendHeader())
+	nameW := clampInt(w-48-trendW, 10, 24)
+	sb.WriteString(StyleTableHeader.Render(fmt.Sprintf("  %-*s %13s %-7s %13s %9s",
+		nameW, "COMMODITY", "PRICE", "UNIT", "PREV CLOSE", "CHANGE")+m.trendHeader()) + "\n")
+	sb.WriteString(StyleDivider.Render("  "+strings.Repeat("─", minInt(w-2, nameW+46+trendW))) + "\n")
+	now := time.Now()
+	nIdx := len(m.shownIndices())
+	for i, c := range m.commodities {
+		sb.WriteString(fmt.Sprintf("%s%-*s %13s %s %13s %s%s%s\n",
+			selectMark(m.activeTab == TabMarkets && nIdx+i == m.selectedMarketIdx), nameW, truncate(c.Name, nameW),
+			markets.FormatPriceIn(c.Price, c.Currency),
+			StyleMuted.Render(fmt.Sprintf("%-7s", c.UnitLabel())),
+			StyleMuted.Render(markets.FormatPriceIn(c.PrevClose, c.Currency)),
+			m.changeStr(c.ChangePct),
+			m.trendCell(commodityHistoryKey(c)),
+			staleMark(c.Stale(now, m.staleAfter())),
+		))
+	}
+	return sb.String()
+}
+
+func (m Model) renderPolyTable(w int) string {
+	if s := m.sectionStatus("poly", len(m.polyMarkets) == 0); s != "" {
+		return s
+	}
+	var sb strings.Builder
+	titleW := maxInt(w-32, 20)
+	sb.WriteString(StyleTableHeader.Render(fmt.Sprintf("  %-*s %6s %10s %10s",
+		titleW, "QUESTION", "YES%", "VOLUME", "ENDS")) + "\n")
+	sb.WriteString(StyleDivider.Render("  "+strings.Repeat("─", minInt(w-2, titleW+30))) + "\n")
+	for i, pm := range m.polyMarkets {
+		pct := fmt.Sprintf("%5.1f%%", pm.Probability*100)
+		if !pm.HasProbability {
+			pct = "—"
+		}
+		vol := "—"
+		if pm.HasVolume {
+			vol = markets.FormatLargeNum(pm.Volume)
+		}
+		endDate := pm.EndDate
+		if len(endDate) >= 10 {
+			endDate = endDate[:10]
+		}
+		if i == m.selectedPolyIdx() {
+			sb.WriteString(StyleSelectedRow.Render(fmt.Sprintf("▶ %-*s %6s %10s %10s",
+				titleW, truncate(pm.Title, titleW), pct, vol, endDate)) + "\n")
+			sb.WriteString(m.renderPolyDetail(pm, w))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  %-*s %6s %s %10s\n",
+			titleW, truncate(pm.Title, titleW),
+			pct,
+			StyleMktCap.Render(fmt.Sprintf("%10s", vol)),
+			endDate,
+		))
+	}
+	return sb.String()
+}
+
+// renderPolyDetail renders the expanded view under the selected market: the
+// full question, every outcome's probability and the Polymarket link.
+func (m Model) renderPolyDetail(pm markets.PredictionMarket, w int) string {
+	var sb strings.Builder
+	indent := "    "
+	for _, line := range strings.Split(wordWrap(pm.Title, maxInt(w-len(indent), 20)), "\n") {
+		sb.WriteString(indent + StyleSelectedTitle.Render(line) + "\n")
+	}
+	if len(pm.Outcomes) > 0 {
+		parts := make([]string, len(pm.Outcomes))
+		for i, o := range pm.Outcomes {
+			parts[i] = fmt.Sprintf("%s %.1f%%", o.Name, o.Probability*100)
+		}
+		sb.WriteString(indent + strings.Join(parts, StyleMuted.Render(" · ")) + "\n")
+	}
+	if url := pm.URL(); url != "" {
+		sb.WriteString(indent + StyleMuted.Render(truncate(url, maxInt(w-len(indent), 20))) + "\n")
+	} else {
+		sb.WriteString(indent + StyleMuted.Render("no Polymarket link") + "\n")
+	}
+	return sb.String() + "\n"
+}
+
+// ─── Trend history ────────────────────────────────────────────────────────────
+
+func cryptoHistoryKey(p markets.CryptoPrice) string { return "crypto:" + p.ID }
+func indexHistoryKey(idx markets.StockIndex) string { return "index:" + idx.Symbol }
+func commodityHistoryKey(c markets.Commodity) string {
+	return "commodity:" + c.Symbol + ":" + c.Currency
+}
+
+// savePriceHistory persists the trend history when price_history_persist
+// is set. The copy is taken here so the write can't race later updates.
+func (m Model) savePriceHistory() {
+	if m.cfg.PriceHistorySave && m.cfg.PriceHistorySize > 0 {
+		go m.priceHistory.Snapshot().Save()
+	}
+}
+
+// trendWidth is the width of the trend column, 0 when it is disabled
+func (m Model) trendWidth() int {
+	return clampInt(m.cfg.PriceHistorySize, 0, 24)
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders the newest width values scaled between their min and
+// max, right-aligned so the latest point always sits in the same column.
+func sparkline(vals []float64, width int) string {
+	if len(vals) > width {
+		vals = vals[len(vals)-width:]
+	}
+	if len(vals) < 2 {
+		return strings.Repeat(" ", width)
+	}
+	lo, hi := vals[0], vals[0]
+	for _, v := range vals {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat(" ", width-len(vals)))
+	for _, v := range vals {
+		i := len(sparkBlocks) / 2
+		if hi > lo {
+			i = int((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		}
+		sb.WriteRune(sparkBlocks[i])
+	}
+	return sb.String()
+}
+
+// trendCell renders the trend column for one asset, colored by the move
+// across the recorded window. Returns "" when the column is disabled.
+func (m Model) trendCell(key string) string {
+	w := m.trendWidth()
+	if w == 0 {
+		return ""
+	}
+	vals := m.priceHistory.Values(key)
+	style := StyleMuted
+	if n := len(vals); n >= 2 {
+		switch {
+		case vals[n-1] > vals[0]:
+			style = StylePositive
+		case vals[n-1] < vals[0]:
+			style = StyleNegative
+		}
+	}
+	return " " + style.Render(sparkline(vals, w))
+}
+
+// trendHeader is the trend column heading, padded to its width
+func (m Model) trendHeader() string {
+	w := m.trendWidth()
+	if w == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" %-*s", w, "TREND")
+}
+
+func clampInt(v, lo, hi int) int {
+	return maxInt(lo, minInt(v, hi))
+}
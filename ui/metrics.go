@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fetchStat accumulates the durations of one section's fetches
+type fetchStat struct {
+	runs  int
+	last  time.Duration
+	total time.Duration
+	max   time.Duration
+}
+
+func (s fetchStat) avg() time.Duration {
+	if s.runs == 0 {
+		return 0
+	}
+	return s.total / time.Duration(s.runs)
+}
+
+// fetchMetrics records how long each section's fetches take. Fetchers run
+// on their own goroutines, so it is shared by pointer and locked.
+type fetchMetrics struct {
+	mu    sync.Mutex
+	stats map[string]*fetchStat
+}
+
+func newFetchMetrics() *fetchMetrics {
+	return &fetchMetrics{stats: make(map[string]*fetchStat)}
+}
+
+func (fm *fetchMetrics) record(key string, d time.Duration) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	s := fm.stats[key]
+	if s == nil {
+		s = &fetchStat{}
+		fm.stats[key] = s
+	}
+	s.runs++
+	s.last = d
+	s.total += d
+	if d > s.max {
+		s.max = d
+	}
+}
+
+// timed wraps fetch so its duration is recorded under key. A fetch that
+// outlives the refresh deadline is still recorded when it finally returns,
+// which is exactly the slow source worth knowing about.
+func (fm *fetchMetrics) timed(key string, fetch fetchFunc) fetchFunc {
+	return func(ctx context.Context) tea.Msg {
+		start := time.Now()
+		msg := fetch(ctx)
+		fm.record(key, time.Since(start))
+		return msg
+	}
+}
+
+type namedStat struct {
+	key string
+	fetchStat
+}
+
+// sorted returns a copy of the stats, slowest average first
+func (fm *fetchMetrics) sorted() []namedStat {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	out := make([]namedStat, 0, len(fm.stats))
+	for key, s := range fm.stats {
+		out = append(out, namedStat{key, *s})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].avg() != out[j].avg() {
+			return out[i].avg() > out[j].avg()
+		}
+		return out[i].key < out[j].key
+	})
+	return out
+}
+
+// FetchSummary is a one-line summary of fetch durations, slowest first,
+// printed on quit with --debug.
+func (m Model) FetchSummary() string {
+	stats := m.metrics.sorted()
+	if len(stats) == 0 {
+		return "fetch times: no fetches completed"
+	}
+	parts := make([]string, len(stats))
+	for i, s := range stats {
+		parts[i] = fmt.Sprintf("%s avg %s max %s (%d runs)", s.key, fmtDuration(s.avg()), fmtDuration(s.max), s.runs)
+	}
+	return "fetch times: " + strings.Join(parts, " · ")
+}
+
+// renderDiagnostics renders the diagnostics overlay: per-section fetch
+// timings and the last error of each section.
+func (m Model) renderDiagnostics() string {
+	var sb strings.Builder
+	sb.WriteString(StyleSectionHeader.Render(" DIAGNOSTICS  fetch timings, slowest first") + "\n\n")
+	stats := m.metrics.sorted()
+	if len(stats) == 0 {
+		sb.WriteString(StyleMuted.Render("  No fetches completed yet.") + "\n")
+	} else {
+		sb.WriteString(StyleTableHeader.Render(fmt.Sprintf("  %-14s %8s %8s %8s %6s", "SECTION", "LAST", "AVG", "MAX", "RUNS")) + "\n")
+		sb.WriteString(StyleDivider.Render("  "+strings.Repeat("─", 48)) + "\n")
+		for _, s := range stats {
+			sb.WriteString(fmt.Sprintf("  %-14s %8s %8s %8s %6d\n",
+				s.key, fmtDuration(s.last), fmtDuration(s.avg()), fmtDuration(s.max), s.runs))
+		}
+	}
+	if len(m.errors) > 0 {
+		keys := make([]string, 0, len(m.errors))
+		for k := range m.errors {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		sb.WriteString("\n" + StyleSubSectionHeader.Render("  ERRORS") + "\n")
+		for _, k := range keys {
+			sb.WriteString(fmt.Sprintf("  %-14s %s\n", k, StyleError.Render(truncate(m.errors[k], maxInt(m.width-24, 20)))))
+		}
+	}
+	return sb.String()
+}
+
+// fmtDuration renders d to the nearest millisecond, e.g. "850ms" or "1.24s"
+func fmtDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
@@ -2,39 +2,40 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 	"watchtower/config"
 	"watchtower/feeds"
+	"watchtower/fixtures"
 	"watchtower/intel"
 	"watchtower/markets"
+	"watchtower/report"
 	"watchtower/weather"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Tab indices — 3 tabs now
-const (
-	TabOverview = iota
-	TabNews
-	TabLocal
-	tabCount
-)
-
 // Message types
 type (
 	globalNewsMsg struct {
-		items []feeds.NewsItem
-		err   error
+		items  []feeds.NewsItem
+		failed []feeds.SourceError
+		err    error
 	}
 	localNewsMsg struct {
-		items []feeds.NewsItem
-		err   error
+		items  []feeds.NewsItem
+		failed []feeds.SourceError
+		err    error
 	}
 	cryptoMsg struct {
 		prices []markets.CryptoPrice
@@ -52,22 +53,38 @@ type (
 		markets []markets.PredictionMarket
 		err     error
 	}
+	countryRisksMsg struct {
+		risks []intel.CountryRisk
+		err   error
+	}
+	alertsMsg struct {
+		items []feeds.NewsItem
+		err   error
+	}
 	weatherMsg struct {
 		cond     *weather.Conditions
 		forecast []weather.DayForecast
+		resolved *config.Location // set when coordinates had to be geocoded
 		err      error
 	}
 	briefMsg struct {
-		brief     *intel.Brief
-		err       error
-		fromCache bool
+		brief      *intel.Brief
+		err        error
+		fromCache  bool
+		background bool         // soft refresh behind a cached brief; failures keep it
+		last       *intel.Brief // on error, the last cached brief of any age
 	}
 	localBriefMsg struct {
 		brief     *intel.LocalBrief
 		err       error
 		fromCache bool
+		safety    bool // the safety variant rather than the summary
+	}
+	tickMsg struct {
+		at    time.Time
+		every time.Duration // the interval whose sections are due
+		gen   int           // refreshTimer generation that armed it
 	}
-	tickMsg time.Time
 )
 
 // openURLMsg triggers opening a URL in the system browser
@@ -76,369 +93,603 @@ type openURLMsg struct{ url string }
 // clearStatusMsg clears the status bar message
 type clearStatusMsg struct{}
 
+// snapshotMsg reports where a Markdown snapshot ended up
+type snapshotMsg struct {
+	copied bool   // true if it went to the clipboard
+	path   string // set when written to a file instead
+	err    error
+}
+
 // Model is the root bubbletea model
 type Model struct {
 	cfg       *config.Config
-	width     int
+	width     int // layout width, capped by max_content_width
+	termWidth int // actual terminal width
 	height    int
 	activeTab int
+	focus     bool // focus mode: only the active pane, no header/tabs/footer
 
 	// Data
 	globalNews   []feeds.NewsItem
+	alerts       []feeds.NewsItem // from webhook_feed_url; shown above globalNews, never sent to the LLM
 	localNews    []feeds.NewsItem
 	cryptoPrices []markets.CryptoPrice
 	stockIndices []markets.StockIndex
 	commodities  []markets.Commodity
 	polyMarkets  []markets.PredictionMarket
+	priceHistory *markets.PriceHistory // prices seen over recent refreshes, for trend sparklines
 	weatherCond  *weather.Conditions
 	forecast     []weather.DayForecast
 	brief        *intel.Brief
 	localBrief   *intel.LocalBrief
+	safetyBrief  *intel.LocalBrief // local_brief_mode: safety
 
 	// News selection (for browser open)
 	selectedNewsIdx      int
-	newsHeaderLines      int // line count of the header above the article list (for scroll tracking)
+	compare              bool               // News tab lists stories covered by several sources, grouped
+	countryFilter        string             // News tab shows only headlines mentioning this country; "" = all
+	riskOrder            riskOrder          // order of the country risk panel rows
+	prevRisks            map[string]int     // scores from the brief before this one, loaded for riskByDelta
+	newsClusters         [][]feeds.NewsItem // feeds.Clusters of the news, kept while compare is on
 	selectedLocalNewsIdx int
-	localNewsHeaderLines int
+	selectedMarketIdx    int  // Markets tab: index rows, then commodity rows, then prediction markets
+	expanded             bool // show the selected article's summary inline
+	diagnostics          bool // show the diagnostics overlay instead of the tab
+	glance               bool // show only the glance view (w) instead of the dashboard
+	confirmQuit          bool // "Quit? (y/n)" is showing in the footer
+	metrics              *fetchMetrics
 	statusMsg            string
 	statusExpiry         time.Time
 
 	// State
-	loading     map[string]bool
-	errors      map[string]string
-	lastRefresh time.Time
-
-	// Viewports for scrollable panes
-	viewports [tabCount]viewport.Model
-	spinner   spinner.Model
+	loading      map[string]bool
+	errors       map[string]string
+	lastRefresh  time.Time
+	lastData     time.Time                       // when a news or market fetch last landed; never cleared, for refresh_on_focus_seconds
+	timers       map[time.Duration]*refreshTimer // refresh tick chains, per interval
+	lastWatchdog time.Time                       // when the watchdog last ran, to notice a resume from sleep
+
+	// Feed sources that failed on the last fetch, keyed "global"/"local"
+	failedSources map[string][]feeds.SourceError
+
+	// Tabs and their scrollable panes, indexed alike
+	tabs        []tabDef
+	viewports   []viewport.Model
+	headerLines []int // per tab: lines above the article list (for scroll tracking)
+	spinner     spinner.Model
+	spinning    bool // a spinner tick chain is running
+
+	// In-terminal article reader overlay
+	reader readerState
 }
 
 func NewModel(cfg *config.Config) Model {
+	if config.ValidHexColor(cfg.AccentColor) {
+		SetAccent(lipgloss.Color(cfg.AccentColor))
+	}
 	sp := spinner.New()
-	sp.Spinner = spinner.Dot
+	sp.Spinner = spinnerByName(cfg.SpinnerStyle)
 	sp.Style = StyleSpinner
 
-	vps := [tabCount]viewport.Model{}
+	tabs := newTabs()
+	vps := make([]viewport.Model, len(tabs))
 	for i := range vps {
 		vps[i] = viewport.New(80, 30)
 	}
 
+	history := markets.NewPriceHistory(cfg.PriceHistorySize)
+	if cfg.PriceHistorySave {
+		history = markets.LoadPriceHistory(cfg.PriceHistorySize)
+	}
+
 	return Model{
-		cfg:       cfg,
-		loading:   make(map[string]bool),
-		errors:    make(map[string]string),
-		spinner:   sp,
-		viewports: vps,
-		activeTab: TabOverview,
+		cfg:          cfg,
+		priceHistory: history,
+		loading:      make(map[string]bool),
+		errors:       make(map[string]string),
+		spinner:      sp,
+		spinning:     true, // Init starts the first refresh and the tick chain
+
+		failedSources: make(map[string][]feeds.SourceError),
+		tabs:          tabs,
+		viewports:     vps,
+		headerLines:   make([]int, len(tabs)),
+		activeTab:     TabOverview,
+		glance:        cfg.GlanceView,
+		reader:        readerState{vp: viewport.New(80, 30)},
+		metrics:       newFetchMetrics(),
+		timers:        newRefreshTimers(refreshIntervals(cfg), time.Now()),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.spinner.Tick,
-		doRefreshAll(m.cfg),
-		tickEvery(time.Duration(m.cfg.RefreshSec)*time.Second),
+		m.startRefreshAll(),
 		loadCachedBrief(m.cfg),
-		loadCachedLocalBrief(m.cfg),
-	)
+		loadCachedLocalBrief(m.cfg, false),
+		loadCachedLocalBrief(m.cfg, true),
+		watchdogTick(),
+	}
+	for _, d := range refreshIntervals(m.cfg) {
+		cmds = append(cmds, tickEvery(d, 0))
+	}
+	return tea.Batch(cmds...)
 }
 
-func tickEvery(d time.Duration) tea.Cmd {
-	return tea.Tick(d, func(t time.Time) tea.Msg { return tickMsg(t) })
+// tickEvery arms the refresh timer for the sections refreshed every d
+func tickEvery(d time.Duration, gen int) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg { return tickMsg{at: t, every: d, gen: gen} })
 }
 
-func doRefreshAll(cfg *config.Config) tea.Cmd {
-	return tea.Batch(
-		fetchGlobalNews(),
-		fetchLocalNews(cfg.Location.City, cfg.Location.Country),
-		fetchCrypto(cfg.CryptoPairs),
-		fetchStocks(),
-		fetchCommodities(),
-		fetchPolymarket(),
-		fetchWeather(cfg.Location.Latitude, cfg.Location.Longitude, cfg.Location.City),
-	)
+// refreshSections are the m.loading keys set by a full refresh, one per fetcher
+var refreshSections = []string{"global", "local", "crypto", "stocks", "commodities", "poly", "weather"}
+
+// activeSections is refreshSections plus the alerts feed when configured
+func activeSections(cfg *config.Config) []string {
+	if cfg.WebhookFeedURL == "" {
+		return refreshSections
+	}
+	return append(refreshSections[:len(refreshSections):len(refreshSections)], "alerts")
+}
+
+// sectionInterval is how often the fetcher behind a section key runs. The
+// per-section settings default to refresh_seconds.
+func sectionInterval(cfg *config.Config, key string) time.Duration {
+	secs := cfg.RefreshSec
+	switch key {
+	case "global", "local", "alerts":
+		secs = cfg.NewsRefreshSec
+	case "crypto":
+		secs = cfg.CryptoRefreshSec
+	case "stocks", "commodities", "poly":
+		secs = cfg.MarketsRefreshSec
+	case "weather":
+		secs = cfg.WeatherRefreshSec
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// refreshIntervals lists the distinct section intervals. Each gets one
+// timer, so sections sharing an interval still refresh as one batch.
+func refreshIntervals(cfg *config.Config) []time.Duration {
+	var out []time.Duration
+	seen := map[time.Duration]bool{}
+	for _, key := range activeSections(cfg) {
+		if d := sectionInterval(cfg, key); !seen[d] {
+			seen[d] = true
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// sectionsEvery lists the sections whose timer fires every d
+func sectionsEvery(cfg *config.Config, d time.Duration) []string {
+	var out []string
+	for _, key := range activeSections(cfg) {
+		if sectionInterval(cfg, key) == d {
+			out = append(out, key)
+		}
+	}
+	return out
+}
+
+// startRefreshAll marks every section as loading and returns the batched fetch.
+func (m Model) startRefreshAll() tea.Cmd {
+	return m.startRefresh(activeSections(m.cfg)...)
+}
+
+// failedSections lists the refresh sections whose last fetch failed and
+// that aren't already being fetched again, in refresh order
+func (m Model) failedSections() []string {
+	var failed []string
+	for _, key := range activeSections(m.cfg) {
+		if _, ok := m.errors[key]; ok && !m.loading[key] {
+			failed = append(failed, key)
+		}
+	}
+	return failed
+}
+
+// startRefresh marks the given sections as loading and returns their
+// batched fetch. The loading map is shared, so this works on the value receiver.
+func (m Model) startRefresh(sections ...string) tea.Cmd {
+	for _, key := range sections {
+		m.loading[key] = true
+	}
+	return doRefresh(m.cfg, m.metrics, sections)
+}
+
+// refreshProgress counts how many of a full refresh's sections have
+// reported back. done == total when no refresh is running.
+func (m Model) refreshProgress() (done, total int) {
+	sections := activeSections(m.cfg)
+	for _, key := range sections {
+		if !m.loading[key] {
+			done++
+		}
+	}
+	return done, len(sections)
+}
+
+// sectionFetch is the fetcher for one section and the message it reports
+// when the refresh deadline passes first
+type sectionFetch struct {
+	fetch     fetchFunc
+	onTimeout func(error) tea.Msg
+}
+
+// sectionFetchers maps each section key to its fetcher
+func sectionFetchers(cfg *config.Config, metrics *fetchMetrics) map[string]sectionFetch {
+	return map[string]sectionFetch{
+		"global": {metrics.timed("global", fetchGlobalNews(FeedOptions(cfg))),
+			func(err error) tea.Msg { return globalNewsMsg{err: err} }},
+		"local": {metrics.timed("local", fetchLocalNews(LocalSources(cfg), FeedOptions(cfg))),
+			func(err error) tea.Msg { return localNewsMsg{err: err} }},
+		"crypto": {metrics.timed("crypto", fetchCrypto(CryptoOptions(cfg), cfg.CryptoPairs)),
+			func(err error) tea.Msg { return cryptoMsg{err: err} }},
+		"stocks": {metrics.timed("stocks", fetchStocks()),
+			func(err error) tea.Msg { return stockMsg{err: err} }},
+		"commodities": {metrics.timed("commodities", fetchCommodities(CommodityOptions(cfg))),
+			func(err error) tea.Msg { return commodityMsg{err: err} }},
+		"poly": {metrics.timed("poly", fetchPolymarket()),
+			func(err error) tea.Msg { return polymarketMsg{err: err} }},
+		"weather": {metrics.timed("weather", fetchWeather(cfg.Location, cfg.WeatherProvider)),
+			func(err error) tea.Msg { return weatherMsg{err: err} }},
+		"alerts": {metrics.timed("alerts", fetchAlerts(cfg.WebhookFeedURL, cfg.WebhookHeaders)),
+			func(err error) tea.Msg { return alertsMsg{err: err} }},
+	}
+}
+
+// doRefresh runs the given sections' fetchers under one parent context with
+// an overall deadline. A fetcher that hasn't answered by then is cancelled
+// and reported as a timeout for its own section, so no spinner runs forever.
+func doRefresh(cfg *config.Config, metrics *fetchMetrics, sections []string) tea.Cmd {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.RefreshTimeoutSec)*time.Second)
+
+	fetchers := sectionFetchers(cfg, metrics)
+	var cmds []tea.Cmd
+	for _, key := range sections {
+		if f, ok := fetchers[key]; ok {
+			cmds = append(cmds, withDeadline(ctx, f.fetch, f.onTimeout))
+		}
+	}
+
+	// Release the context as soon as every section has reported
+	var wg sync.WaitGroup
+	wg.Add(len(cmds))
+	for i, cmd := range cmds {
+		cmd := cmd
+		cmds[i] = func() tea.Msg {
+			defer wg.Done()
+			return cmd()
+		}
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+	}()
+
+	return tea.Batch(cmds...)
+}
+
+// fetchFunc is a section fetcher that honours context cancellation
+type fetchFunc func(ctx context.Context) tea.Msg
+
+// withDeadline runs fetch under ctx. If ctx expires first it returns
+// onTimeout instead, so the section's loading flag is always cleared even
+// when the fetcher ignores cancellation; its late result is dropped.
+func withDeadline(ctx context.Context, fetch fetchFunc, onTimeout func(error) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		done := make(chan tea.Msg, 1)
+		go func() { done <- fetch(ctx) }()
+		select {
+		case msg := <-done:
+			return msg
+		case <-ctx.Done():
+			return onTimeout(errors.New("timed out waiting for a response"))
+		}
+	}
 }
 
 // ─── Update ───────────────────────────────────────────────────────────────────
 
+// Update handles msg, then makes sure the spinner animates for exactly as
+// long as something is loading: a tick chain is started whenever work is
+// in flight without one, and handleMsg lets it lapse once idle.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.handleMsg(msg)
+	nm, ok := next.(Model)
+	if !ok || nm.spinning || !nm.busy() {
+		return next, cmd
+	}
+	nm.spinning = true
+	return nm, tea.Batch(cmd, nm.spinner.Tick)
+}
+
+// busy reports whether any section, brief or the article reader is loading
+func (m Model) busy() bool {
+	return len(m.loading) > 0 || (m.reader.open && m.reader.loading)
+}
+
+func (m Model) handleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if isFocusIn(msg) {
+		msg = focusMsg{}
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
+		m.termWidth = msg.Width
 		m.width = msg.Width
-		m.height = msg.Height
-		contentH := m.height - 6
+		if m.cfg.MaxContentWidth > 0 && m.width > m.cfg.MaxContentWidth {
+			m.width = m.cfg.MaxContentWidth
+		}
+		// Below the floor the layout overflows instead of computing
+		// negative widths
+		m.width = maxInt(m.width, minLayoutWidth)
+		m.height = maxInt(msg.Height, minLayoutHeight)
+		contentH := m.paneHeight()
 		for i := range m.viewports {
-			m.viewports[i].Width = msg.Width - 4
+			m.viewports[i].Width = m.width - 4
 			m.viewports[i].Height = contentH
 		}
-		m.viewports[TabOverview].SetContent(m.renderOverviewContent())
+		m.reader.vp.Width = m.width - 4
+		m.reader.vp.Height = contentH
+		if m.reader.open {
+			m.reader.vp.SetContent(m.renderReaderContent())
+		}
+		// Data that arrived before the first size was never laid out
+		for i := range m.tabs {
+			m.rerender(i)
+		}
 
 	case tea.KeyMsg:
+		if m.confirmQuit {
+			// y or a second ctrl+c quits; any other key cancels
+			m.confirmQuit = false
+			if s := msg.String(); s == "y" || s == "Y" || s == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		if msg.String() == "ctrl+c" || (msg.String() == "q" && !m.reader.open) {
+			if !m.cfg.ConfirmQuit {
+				return m, tea.Quit
+			}
+			m.confirmQuit = true
+			return m, nil
+		}
+		if m.reader.open {
+			return m.updateReader(msg)
+		}
 		switch msg.String() {
-		case "q", "ctrl+c":
-			return m, tea.Quit
 		case "tab", "right", "l":
-			m.activeTab = (m.activeTab + 1) % tabCount
-			cmds = append(cmds, func() tea.Msg {
-				return tea.WindowSizeMsg{
-					Width:  m.width,
-					Height: m.height,
-				}
-			})
+			cmds = append(cmds, m.switchTab(m.activeTab+1))
 		case "shift+tab", "left", "h":
-			m.activeTab = (m.activeTab - 1 + tabCount) % tabCount
-		case "1":
-			m.activeTab = TabOverview
-		case "2":
-			m.activeTab = TabNews
-		case "3":
-			m.activeTab = TabLocal
-			cmds = append(cmds, func() tea.Msg {
-				return tea.WindowSizeMsg{
-					Width:  m.width,
-					Height: m.height,
-				}
-			})
+			cmds = append(cmds, m.switchTab(m.activeTab-1))
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			if n := int(msg.String()[0] - '1'); n < len(m.tabs) {
+				cmds = append(cmds, m.switchTab(n))
+			}
+		case "f":
+			m.focus = !m.focus
+			cmds = append(cmds, m.redraw())
+		case "w":
+			m.glance = !m.glance
+			m.cfg.GlanceView = m.glance
+			if err := config.SaveSetting("glance_view", m.glance); err != nil {
+				m.statusMsg = "Could not save glance view setting: " + err.Error()
+				m.statusExpiry = time.Now().Add(3 * time.Second)
+			}
+			if !m.glance {
+				cmds = append(cmds, m.redraw())
+			}
+		case "D":
+			m.diagnostics = !m.diagnostics
 		case "r":
 			m.lastRefresh = time.Time{}
-			cmds = append(cmds, doRefreshAll(m.cfg))
+			cmds = append(cmds, m.startRefreshAll())
+		case "x":
+			// Retry only what failed, sparing rate limits after a network blip
+			retry := m.failedSections()
+			if len(retry) > 0 {
+				cmds = append(cmds, m.startRefresh(retry...))
+			}
+			// A failed brief is retried from the current headlines, unless
+			// the news is retried too: its arrival triggers the brief anyway
+			_, briefFailed := m.errors["brief"]
+			if briefFailed && m.cfg.LLMAPIKey != "" && !m.loading["brief"] && !slices.Contains(retry, "global") {
+				m.loading["brief"] = true
+				cmds = append(cmds, fetchBrief(LLMConfig(m.cfg), m.globalNews, m.briefOptions(), m.cfg.BriefCacheMins, false))
+				retry = append(retry, "brief")
+			}
+			m.statusMsg = "Nothing failed; r refreshes everything"
+			if len(retry) > 0 {
+				m.statusMsg = "Retrying: " + strings.Join(retry, ", ")
+			}
+			m.statusExpiry = time.Now().Add(3 * time.Second)
+		case "s":
+			cmds = append(cmds, copySnapshot(report.Markdown(m.snapshot())))
+		case "y":
+			if m.brief == nil {
+				m.statusMsg = "No brief to copy yet (b to generate)"
+				m.statusExpiry = time.Now().Add(3 * time.Second)
+			} else {
+				cmds = append(cmds, copyText(report.BriefMarkdown(m.brief.WithSections(BriefOptions(m.cfg))), "brief"))
+			}
 		case "b":
 			if m.cfg.LLMAPIKey != "" {
 				m.loading["brief"] = true
-				cmds = append(cmds, fetchBrief(intel.LLMConfig{Provider: intel.Provider(m.cfg.LLMProvider), APIKey: m.cfg.LLMAPIKey, Model: m.cfg.LLMModel}, m.globalNews, m.cfg.BriefCacheMins, false))
+				cmds = append(cmds, fetchBrief(LLMConfig(m.cfg), m.globalNews, m.briefOptions(), m.cfg.BriefCacheMins, false))
 			}
 		case "B":
 			if m.cfg.LLMAPIKey != "" {
 				m.loading["brief"] = true
 				m.statusMsg = "Forcing fresh brief (ignoring cache)..."
 				m.statusExpiry = time.Now().Add(3 * time.Second)
-				cmds = append(cmds, fetchBrief(intel.LLMConfig{Provider: intel.Provider(m.cfg.LLMProvider), APIKey: m.cfg.LLMAPIKey, Model: m.cfg.LLMModel}, m.globalNews, m.cfg.BriefCacheMins, true))
+				cmds = append(cmds, fetchBrief(LLMConfig(m.cfg), m.globalNews, m.briefOptions(), m.cfg.BriefCacheMins, true))
+			}
+		case "C":
+			// Re-score just the country risk index, keeping the rest of the brief
+			switch {
+			case m.cfg.LLMAPIKey == "" || !m.cfg.BriefSections.CountryRisks:
+			case m.brief == nil:
+				m.statusMsg = "Generate a brief first (b)"
+				m.statusExpiry = time.Now().Add(3 * time.Second)
+			case !m.loading["risks"] && !m.loading["brief"]:
+				m.loading["risks"] = true
+				cmds = append(cmds, fetchCountryRisks(LLMConfig(m.cfg), m.globalNews, BriefOptions(m.cfg)))
+			}
+		case "R":
+			if m.activeTab == TabNews && m.cfg.BriefSections.CountryRisks {
+				m.cfg.NewsRiskPanel = !m.cfg.NewsRiskPanel
+				if err := config.SaveSetting("news_risk_panel", m.cfg.NewsRiskPanel); err != nil {
+					m.statusMsg = "Could not save risk panel setting: " + err.Error()
+					m.statusExpiry = time.Now().Add(3 * time.Second)
+				}
+				// Re-clamp the selection so scrolling uses the new header height
+				cmds = append(cmds, m.moveSelection(m.selectedNewsIdx))
 			}
 		case "i":
 			if m.cfg.LLMAPIKey != "" && m.activeTab == TabLocal {
-				m.loading["localBrief"] = true
-				cmds = append(cmds, fetchLocalBrief(intel.LLMConfig{Provider: intel.Provider(m.cfg.LLMProvider), APIKey: m.cfg.LLMAPIKey, Model: m.cfg.LLMModel}, m.cfg.Location.City, m.localNews, m.weatherCond, m.forecast, m.cfg.BriefCacheMins, false))
+				cmds = append(cmds, m.requestLocalBrief(false))
 			}
 		case "I":
 			if m.cfg.LLMAPIKey != "" && m.activeTab == TabLocal {
-				m.loading["localBrief"] = true
 				m.statusMsg = "Forcing fresh local brief (ignoring cache)..."
 				m.statusExpiry = time.Now().Add(3 * time.Second)
-				cmds = append(cmds, fetchLocalBrief(intel.LLMConfig{Provider: intel.Provider(m.cfg.LLMProvider), APIKey: m.cfg.LLMAPIKey, Model: m.cfg.LLMModel}, m.cfg.Location.City, m.localNews, m.weatherCond, m.forecast, m.cfg.BriefCacheMins, true))
+				cmds = append(cmds, m.requestLocalBrief(true))
 			}
-		case "j", "down":
-			if m.activeTab == TabNews && len(m.globalNews) > 0 {
-				m.selectedNewsIdx = minInt(m.selectedNewsIdx+1, len(m.globalNews)-1)
-				{
-					newsContent, hdrLines := m.renderNewsContent()
-					m.newsHeaderLines = hdrLines
-					m.viewports[TabNews].SetContent(newsContent)
+		case "F":
+			switch {
+			case m.countryFilter != "":
+				m.countryFilter = ""
+			case m.brief == nil:
+				// no brief to take a country from
+			default:
+				m.countryFilter = intel.TopCountry(m.brief)
+				if m.countryFilter == "" {
+					m.statusMsg = "The brief mentions no country to filter by"
+					m.statusExpiry = time.Now().Add(3 * time.Second)
+					break
 				}
-				scrollNewsIntoView(&m.viewports[TabNews], m.newsHeaderLines, m.selectedNewsIdx)
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			} else if m.activeTab == TabLocal && len(m.localNews) > 0 {
-				m.selectedLocalNewsIdx = minInt(m.selectedLocalNewsIdx+1, len(m.localNews)-1)
-				{
-					newsContent, hdrLines := m.renderLocalContent()
-					m.localNewsHeaderLines = hdrLines
-					m.viewports[TabLocal].SetContent(newsContent)
+				cmds = append(cmds, m.switchTab(TabNews))
+			}
+			if m.activeTab == TabNews {
+				m.updateClusters()
+				cmds = append(cmds, m.moveSelection(0))
+			}
+		case "O":
+			if m.activeTab == TabNews && m.cfg.BriefSections.CountryRisks && m.cfg.NewsRiskPanel {
+				m.riskOrder = (m.riskOrder + 1) % 3
+				if m.riskOrder == riskByDelta {
+					m.loadPrevRisks()
 				}
-				scrollNewsIntoView(&m.viewports[TabLocal], m.localNewsHeaderLines, m.selectedLocalNewsIdx)
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			} else {
-				m.viewports[m.activeTab].LineDown(1)
+				m.rerender(TabNews)
 			}
-		case "k", "up":
-			if m.activeTab == TabNews && len(m.globalNews) > 0 {
-				m.selectedNewsIdx = maxInt(m.selectedNewsIdx-1, 0)
-				{
-					newsContent, hdrLines := m.renderNewsContent()
-					m.newsHeaderLines = hdrLines
-					m.viewports[TabNews].SetContent(newsContent)
+		case "[", "]":
+			// Select a risk panel row, showing the headlines behind its score
+			if m.activeTab == TabNews && m.cfg.BriefSections.CountryRisks && m.cfg.NewsRiskPanel {
+				dir := 1
+				if msg.String() == "[" {
+					dir = -1
 				}
-				scrollNewsIntoView(&m.viewports[TabNews], m.newsHeaderLines, m.selectedNewsIdx)
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			} else if m.activeTab == TabLocal && len(m.localNews) > 0 {
-				m.selectedLocalNewsIdx = maxInt(m.selectedLocalNewsIdx-1, 0)
-				{
-					newsContent, hdrLines := m.renderLocalContent()
-					m.localNewsHeaderLines = hdrLines
-					m.viewports[TabLocal].SetContent(newsContent)
+				if c := m.stepRiskCountry(dir); c != "" {
+					m.countryFilter = c
+					m.updateClusters()
+					cmds = append(cmds, m.moveSelection(0))
 				}
-				scrollNewsIntoView(&m.viewports[TabLocal], m.localNewsHeaderLines, m.selectedLocalNewsIdx)
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			} else {
-				m.viewports[m.activeTab].LineUp(1)
 			}
-		case "enter":
-			if m.activeTab == TabNews && m.selectedNewsIdx < len(m.globalNews) {
-				item := m.globalNews[m.selectedNewsIdx]
-				if item.URL != "" {
-					cmds = append(cmds, openURL(item.URL))
-					m.statusMsg = "Opening: " + truncate(item.Title, 60)
-					m.statusExpiry = time.Now().Add(3 * time.Second)
+		case "m":
+			if m.activeTab == TabNews {
+				m.compare = !m.compare
+				m.updateClusters()
+				cmds = append(cmds, m.moveSelection(0))
+			}
+		case "S":
+			if m.activeTab == TabLocal {
+				if m.safetyMode() {
+					m.cfg.LocalBriefMode = "summary"
 				} else {
-					m.statusMsg = "No URL available for this article"
-					m.statusExpiry = time.Now().Add(3 * time.Second)
+					m.cfg.LocalBriefMode = "safety"
 				}
-			} else if m.activeTab == TabLocal && m.selectedLocalNewsIdx < len(m.localNews) {
-				item := m.localNews[m.selectedLocalNewsIdx]
-				if item.URL != "" {
-					cmds = append(cmds, openURL(item.URL))
-					m.statusMsg = "Opening: " + truncate(item.Title, 60)
-					m.statusExpiry = time.Now().Add(3 * time.Second)
-				} else {
-					m.statusMsg = "No URL available for this article"
+				if err := config.SaveSetting("local_brief_mode", m.cfg.LocalBriefMode); err != nil {
+					m.statusMsg = "Could not save local brief mode: " + err.Error()
 					m.statusExpiry = time.Now().Add(3 * time.Second)
 				}
+				if m.cfg.LLMAPIKey != "" && m.shownLocalBrief() == nil && !m.loading[m.localBriefKey()] &&
+					(len(m.localNews) > 0 || m.weatherCond != nil) {
+					cmds = append(cmds, m.requestLocalBrief(false))
+				}
+				// Re-clamp the selection so scrolling uses the new header height
+				cmds = append(cmds, m.moveSelection(m.selectedLocalNewsIdx))
+			}
+		case "j", "down":
+			if l := m.activeList(); l != nil && l.size(m) > 0 {
+				cmds = append(cmds, m.moveSelection(*l.selected(&m)+1))
+			} else {
+				m.viewports[m.activeTab].LineDown(1)
+			}
+		case "k", "up":
+			if l := m.activeList(); l != nil && l.size(m) > 0 {
+				cmds = append(cmds, m.moveSelection(*l.selected(&m)-1))
+			} else {
+				m.viewports[m.activeTab].LineUp(1)
+			}
+		case "enter":
+			if item, ok := m.selectedArticle(); ok {
+				cmds = append(cmds, m.articleAction(m.cfg.EnterAction, item))
+			} else if pm, ok := m.selectedMarket(); ok {
+				cmds = append(cmds, m.openMarket(pm))
+			} else if q, ok := m.selectedQuote(); ok {
+				cmds = append(cmds, m.openQuote(q))
+			}
+		case "o":
+			if item, ok := m.selectedArticle(); ok {
+				cmds = append(cmds, m.articleAction(actionBrowser, item))
+			} else if pm, ok := m.selectedMarket(); ok {
+				cmds = append(cmds, m.openMarket(pm))
+			} else if q, ok := m.selectedQuote(); ok {
+				cmds = append(cmds, m.openQuote(q))
+			}
+		case "v":
+			if item, ok := m.selectedArticle(); ok {
+				cmds = append(cmds, m.articleAction(actionReader, item))
+			}
+		case "c":
+			if item, ok := m.selectedArticle(); ok {
+				cmds = append(cmds, m.articleAction(actionCopy, item))
+			}
+		case "e":
+			if m.activeList() != nil && m.activeList().items != nil {
+				m.expanded = !m.expanded
+				m.rerender(m.activeTab)
 			}
 		case "d":
-			switch m.activeTab {
-			case TabNews:
-				m.selectedNewsIdx = minInt(m.selectedNewsIdx+10, maxInt(len(m.globalNews)-1, 0))
-				{
-					newsContent, hdrLines := m.renderNewsContent()
-					m.newsHeaderLines = hdrLines
-					m.viewports[TabNews].SetContent(newsContent)
-				}
-				scrollNewsIntoView(&m.viewports[TabNews], m.newsHeaderLines, m.selectedNewsIdx)
-				// Force a redraw by sending a WindowSizeMsg
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			case TabLocal:
-				m.selectedLocalNewsIdx = minInt(m.selectedLocalNewsIdx+10, maxInt(len(m.localNews)-1, 0))
-				{
-					newsContent, hdrLines := m.renderLocalContent()
-					m.localNewsHeaderLines = hdrLines
-					m.viewports[TabLocal].SetContent(newsContent)
-				}
-				scrollNewsIntoView(&m.viewports[TabLocal], m.localNewsHeaderLines, m.selectedLocalNewsIdx)
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			default:
+			if l := m.activeList(); l != nil {
+				cmds = append(cmds, m.moveSelection(*l.selected(&m)+10))
+			} else {
 				m.viewports[m.activeTab].HalfViewDown()
 			}
 		case "u":
-			switch m.activeTab {
-			case TabNews:
-				m.selectedNewsIdx = maxInt(m.selectedNewsIdx-10, 0)
-				{
-					newsContent, hdrLines := m.renderNewsContent()
-					m.newsHeaderLines = hdrLines
-					m.viewports[TabNews].SetContent(newsContent)
-				}
-				scrollNewsIntoView(&m.viewports[TabNews], m.newsHeaderLines, m.selectedNewsIdx)
-				// Force a redraw by sending a WindowSizeMsg
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			case TabLocal:
-				m.selectedLocalNewsIdx = maxInt(m.selectedLocalNewsIdx-10, 0)
-				{
-					newsContent, hdrLines := m.renderLocalContent()
-					m.localNewsHeaderLines = hdrLines
-					m.viewports[TabLocal].SetContent(newsContent)
-				}
-				scrollNewsIntoView(&m.viewports[TabLocal], m.localNewsHeaderLines, m.selectedLocalNewsIdx)
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			default:
+			if l := m.activeList(); l != nil {
+				cmds = append(cmds, m.moveSelection(*l.selected(&m)-10))
+			} else {
 				m.viewports[m.activeTab].HalfViewUp()
 			}
 		case "G":
-			switch m.activeTab {
-			case TabNews:
-				m.selectedNewsIdx = maxInt(len(m.globalNews)-1, 0)
-				{
-					newsContent, hdrLines := m.renderNewsContent()
-					m.newsHeaderLines = hdrLines
-					m.viewports[TabNews].SetContent(newsContent)
-				}
-				scrollNewsIntoView(&m.viewports[TabNews], m.newsHeaderLines, m.selectedNewsIdx)
-				// Force a redraw by sending a WindowSizeMsg
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			case TabLocal:
-				m.selectedLocalNewsIdx = maxInt(len(m.localNews)-1, 0)
-				{
-					newsContent, hdrLines := m.renderLocalContent()
-					m.localNewsHeaderLines = hdrLines
-					m.viewports[TabLocal].SetContent(newsContent)
-				}
-				scrollNewsIntoView(&m.viewports[TabLocal], m.localNewsHeaderLines, m.selectedLocalNewsIdx)
-			default:
+			if l := m.activeList(); l != nil {
+				cmds = append(cmds, m.moveSelection(l.size(m)-1))
+			} else {
 				m.viewports[m.activeTab].GotoBottom()
 			}
 		case "g":
-			switch m.activeTab {
-			case TabNews:
-				m.selectedNewsIdx = 0
-				newsContent, hdrLines := m.renderNewsContent()
-				m.newsHeaderLines = hdrLines
-				m.viewports[TabNews].SetContent(newsContent)
-				m.viewports[TabNews].GotoTop()
-
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			case TabLocal:
-				m.selectedLocalNewsIdx = 0
-				newsContent, hdrLines := m.renderLocalContent()
-				m.localNewsHeaderLines = hdrLines
-				m.viewports[TabLocal].SetContent(newsContent)
-				m.viewports[TabLocal].GotoTop()
-
-				cmds = append(cmds, func() tea.Msg {
-					return tea.WindowSizeMsg{
-						Width:  m.width,
-						Height: m.height,
-					}
-				})
-			default:
+			if l := m.activeList(); l != nil {
+				cmds = append(cmds, m.moveSelection(0))
+			} else {
 				m.viewports[m.activeTab].GotoTop()
 			}
 		}
@@ -446,85 +697,167 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
+		cmds = append(cmds, m.checkTimers(time.Now()))
+		if !m.busy() {
+			// Let the tick chain die while idle, and repaint every pane so
+			// no cached content keeps a frozen spinner frame.
+			m.spinning = false
+			for i := range m.tabs {
+				m.rerender(i)
+			}
+			break
+		}
 		cmds = append(cmds, cmd)
-		// Keep overview spinner animated while loading
-		if len(m.loading) > 0 {
-			m.viewports[TabOverview].SetContent(m.renderOverviewContent())
+		// Keep each pane's spinner animated while one of its sections loads
+		for i, t := range m.tabs {
+			if m.anyLoading(t.sections...) {
+				m.rerender(i)
+			}
+		}
+		if m.reader.open && m.reader.loading {
+			m.reader.vp.SetContent(m.renderReaderContent())
 		}
 
+	case focusMsg:
+		if m.shouldRefreshOnFocus() {
+			m.lastRefresh = time.Time{}
+			cmds = append(cmds, m.startRefreshAll())
+		}
+
+	case watchdogMsg:
+		now := time.Time(msg)
+		if m.resumed(m.lastWatchdog, now, watchdogEvery) {
+			cmds = append(cmds, m.restartTimers(now))
+		} else {
+			cmds = append(cmds, m.checkTimers(now))
+		}
+		m.lastWatchdog = now
+		cmds = append(cmds, watchdogTick())
+
 	case tickMsg:
-		m.lastRefresh = time.Time{}
-		cmds = append(cmds,
-			doRefreshAll(m.cfg),
-			tickEvery(time.Duration(m.cfg.RefreshSec)*time.Second),
-		)
+		t := m.timers[msg.every]
+		if t == nil || msg.gen != t.gen {
+			break // a chain the watchdog already replaced
+		}
+		if m.resumed(t.last, msg.at, msg.every) {
+			cmds = append(cmds, m.restartTimers(msg.at))
+			break
+		}
+		t.last = msg.at
+		// During quiet hours keep the timer armed but skip the fetch;
+		// a manual r still refreshes.
+		if m.cfg.QuietHours.Active(msg.at) {
+			// Re-render so time-based styling (ages, BREAKING) still decays
+			m.rerender(m.activeTab)
+			cmds = append(cmds, tickEvery(msg.every, msg.gen))
+			break
+		}
+		sections := sectionsEvery(m.cfg, msg.every)
+		for _, key := range sections {
+			if key == "global" {
+				m.lastRefresh = time.Time{}
+			}
+		}
+		cmds = append(cmds, m.startRefresh(sections...), tickEvery(msg.every, msg.gen))
 
 	case globalNewsMsg:
 		delete(m.loading, "global")
+		m.lastData = time.Now()
+		m.failedSources["global"] = msg.failed
 		if msg.err != nil {
 			m.errors["global"] = msg.err.Error()
 		} else {
 			m.globalNews = msg.items
 			delete(m.errors, "global")
-			if m.cfg.LLMAPIKey != "" && m.brief == nil {
-				m.loading["brief"] = true
-				cmds = append(cmds, fetchBrief(intel.LLMConfig{Provider: intel.Provider(m.cfg.LLMProvider), APIKey: m.cfg.LLMAPIKey, Model: m.cfg.LLMModel}, m.globalNews, m.cfg.BriefCacheMins, false))
+			m.updateClusters()
+			if m.cfg.LLMAPIKey == "" {
+				m.brief = intel.HeuristicBrief(m.globalNews)
+			} else if m.cfg.BriefTrigger == "news" || m.cfg.BriefTrigger == "overview" && m.activeTab == TabOverview {
+				cmds = append(cmds, m.autoBrief(), m.softRefreshBrief())
 			}
 		}
-		{
-			newsContent, hdrLines := m.renderNewsContent()
-			m.newsHeaderLines = hdrLines
-			m.viewports[TabNews].SetContent(newsContent)
+		m.rerender(TabNews)
+		m.rerender(TabOverview)
+		if m.cfg.CrossDedup != "off" {
+			m.rerender(TabLocal)
 		}
-		m.viewports[TabOverview].SetContent(m.renderOverviewContent())
+
+	case alertsMsg:
+		delete(m.loading, "alerts")
+		if msg.err != nil {
+			m.errors["alerts"] = msg.err.Error()
+		} else {
+			m.alerts = msg.items
+			delete(m.errors, "alerts")
+			m.updateClusters()
+		}
+		m.rerender(TabNews)
+		m.rerender(TabOverview) // alerts count toward the overview stats
 
 	case localNewsMsg:
 		delete(m.loading, "local")
+		m.lastData = time.Now()
+		m.failedSources["local"] = msg.failed
 		if msg.err != nil {
 			m.errors["local"] = msg.err.Error()
 		} else {
 			m.localNews = msg.items
 			delete(m.errors, "local")
-			if m.cfg.LLMAPIKey != "" && m.localBrief == nil && m.weatherCond != nil {
-				m.loading["localBrief"] = true
-				cmds = append(cmds, fetchLocalBrief(intel.LLMConfig{Provider: intel.Provider(m.cfg.LLMProvider), APIKey: m.cfg.LLMAPIKey, Model: m.cfg.LLMModel}, m.cfg.Location.City, m.localNews, m.weatherCond, m.forecast, m.cfg.BriefCacheMins, false))
+			if m.cfg.LLMAPIKey != "" && m.shownLocalBrief() == nil && m.weatherCond != nil {
+				cmds = append(cmds, m.requestLocalBrief(false))
 			}
 		}
-		{
-			content, hdrLines := m.renderLocalContent()
-			m.localNewsHeaderLines = hdrLines
-			m.viewports[TabLocal].SetContent(content)
-		}
+		m.rerender(TabLocal)
 
 	case cryptoMsg:
 		delete(m.loading, "crypto")
+		m.lastData = time.Now()
 		if msg.err != nil {
 			m.errors["crypto"] = msg.err.Error()
 		} else {
 			m.cryptoPrices = msg.prices
 			delete(m.errors, "crypto")
+			for _, p := range msg.prices {
+				m.priceHistory.Record(cryptoHistoryKey(p), p.PriceUSD)
+			}
+			m.savePriceHistory()
 		}
-		m.viewports[TabOverview].SetContent(m.renderOverviewContent())
+		m.rerender(TabOverview)
+		m.rerender(TabMarkets)
 
 	case stockMsg:
 		delete(m.loading, "stocks")
+		m.lastData = time.Now()
 		if msg.err != nil {
 			m.errors["stocks"] = msg.err.Error()
 		} else {
 			m.stockIndices = msg.indices
 			delete(m.errors, "stocks")
+			for _, idx := range msg.indices {
+				m.priceHistory.Record(indexHistoryKey(idx), idx.Price)
+			}
+			m.savePriceHistory()
 		}
-		m.viewports[TabOverview].SetContent(m.renderOverviewContent())
+		m.rerender(TabOverview)
+		m.rerender(TabMarkets)
 
 	case commodityMsg:
 		delete(m.loading, "commodities")
-		if msg.err != nil {
+		m.lastData = time.Now()
+		// A failed FX conversion still returns USD prices; show those (the
+		// $ unit label makes the fallback visible) rather than an error.
+		if msg.err != nil && len(msg.commodities) == 0 {
 			m.errors["commodities"] = msg.err.Error()
 		} else {
 			m.commodities = msg.commodities
 			delete(m.errors, "commodities")
+			for _, c := range msg.commodities {
+				m.priceHistory.Record(commodityHistoryKey(c), c.Price)
+			}
+			m.savePriceHistory()
 		}
-		m.viewports[TabOverview].SetContent(m.renderOverviewContent())
+		m.rerender(TabOverview)
+		m.rerender(TabMarkets)
 
 	case polymarketMsg:
 		delete(m.loading, "poly")
@@ -534,75 +867,147 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.polyMarkets = msg.markets
 			delete(m.errors, "poly")
 		}
-		m.viewports[TabOverview].SetContent(m.renderOverviewContent())
+		m.rerender(TabOverview)
+		m.rerender(TabMarkets)
 
 	case weatherMsg:
 		delete(m.loading, "weather")
+		if msg.resolved != nil {
+			m.cfg.Location = *msg.resolved
+		}
 		if msg.err != nil {
 			m.errors["weather"] = msg.err.Error()
 		} else {
 			m.weatherCond = msg.cond
 			m.forecast = msg.forecast
 			delete(m.errors, "weather")
-			if m.cfg.LLMAPIKey != "" && m.localBrief == nil && len(m.localNews) > 0 {
-				m.loading["localBrief"] = true
-				cmds = append(cmds, fetchLocalBrief(intel.LLMConfig{Provider: intel.Provider(m.cfg.LLMProvider), APIKey: m.cfg.LLMAPIKey, Model: m.cfg.LLMModel}, m.cfg.Location.City, m.localNews, m.weatherCond, m.forecast, m.cfg.BriefCacheMins, false))
+			if m.cfg.LLMAPIKey != "" && m.shownLocalBrief() == nil && len(m.localNews) > 0 {
+				cmds = append(cmds, m.requestLocalBrief(false))
 			}
 		}
-		{
-			content, hdrLines := m.renderLocalContent()
-			m.localNewsHeaderLines = hdrLines
-			m.viewports[TabLocal].SetContent(content)
-		}
-		m.viewports[TabOverview].SetContent(m.renderOverviewContent())
+		m.rerender(TabLocal)
+		m.rerender(TabOverview)
 
 	case briefMsg:
-		delete(m.loading, "brief")
-		if msg.err != nil {
+		if msg.background {
+			delete(m.loading, "briefRefresh")
+		} else {
+			delete(m.loading, "brief")
+		}
+		if msg.err != nil && msg.background {
+			m.statusMsg = "Background brief refresh failed: " + msg.err.Error()
+			m.statusExpiry = time.Now().Add(4 * time.Second)
+		} else if msg.err != nil {
+			// Keep the brief on screen; the error is shown as a banner above it
 			m.errors["brief"] = msg.err.Error()
+			if m.brief == nil && msg.last != nil {
+				m.brief = msg.last
+			}
 		} else {
 			m.brief = msg.brief
 			delete(m.errors, "brief")
+			if m.riskOrder == riskByDelta {
+				m.loadPrevRisks()
+			}
 			m.lastRefresh = time.Now()
 			if msg.fromCache {
 				m.statusMsg = "Brief loaded from cache (" + msg.brief.GeneratedAt.Format("Jan 02 15:04") + ")"
+				cmds = append(cmds, m.softRefreshBrief())
 			} else {
-				// Persist fresh result to disk cache
+				// Persist fresh result to disk cache and the risk time series
 				go intel.SaveCachedBrief(msg.brief)
+				go intel.AppendRiskHistory(msg.brief)
 				m.statusMsg = "Brief generated and cached"
 			}
 			m.statusExpiry = time.Now().Add(4 * time.Second)
 		}
-		m.viewports[TabOverview].SetContent(m.renderOverviewContent())
+		m.rerender(TabOverview)
 		// Re-render news pane too so country risk header updates
-		{
-			newsContent, hdrLines := m.renderNewsContent()
-			m.newsHeaderLines = hdrLines
-			m.viewports[TabNews].SetContent(newsContent)
+		m.rerender(TabNews)
+
+	case countryRisksMsg:
+		delete(m.loading, "risks")
+		if msg.err != nil {
+			m.statusMsg = "Country risk refresh failed: " + msg.err.Error()
+		} else if m.brief != nil {
+			merged := *m.brief
+			merged.CountryRisks = msg.risks
+			// New scores get their own timestamp, so the risk history gains
+			// a distinct row set and the old scores become "previous"
+			merged.GeneratedAt = time.Now()
+			m.brief = &merged
+			go intel.SaveCachedBrief(m.brief)
+			go intel.AppendRiskHistory(m.brief)
+			m.loadPrevRisks()
+			m.statusMsg = "Country risk index regenerated"
 		}
+		m.statusExpiry = time.Now().Add(4 * time.Second)
+		m.rerender(TabNews)
 
 	case localBriefMsg:
-		delete(m.loading, "localBrief")
+		key, name := localBriefKey(msg.safety), "Local brief"
+		if msg.safety {
+			name = "Safety brief"
+		}
+		delete(m.loading, key)
 		if msg.err != nil {
-			m.errors["localBrief"] = msg.err.Error()
+			m.errors[key] = msg.err.Error()
 		} else {
-			m.localBrief = msg.brief
-			delete(m.errors, "localBrief")
+			delete(m.errors, key)
 			m.lastRefresh = time.Now()
-			if msg.fromCache {
-				m.statusMsg = "Local brief loaded from cache (" + msg.brief.GeneratedAt.Format("Jan 02 15:04") + ")"
+			if msg.safety {
+				m.safetyBrief = msg.brief
 			} else {
+				m.localBrief = msg.brief
+			}
+			switch {
+			case !msg.fromCache && msg.safety:
+				go intel.SaveCachedSafetyBrief(msg.brief)
+			case !msg.fromCache:
 				go intel.SaveCachedLocalBrief(msg.brief)
-				m.statusMsg = "Local brief generated and cached"
 			}
-			m.statusExpiry = time.Now().Add(4 * time.Second)
+			// The other mode's cache is loaded quietly on startup
+			if msg.safety == m.safetyMode() {
+				if msg.fromCache {
+					m.statusMsg = name + " loaded from cache (" + msg.brief.GeneratedAt.Format("Jan 02 15:04") + ")"
+				} else {
+					m.statusMsg = name + " generated and cached"
+				}
+				m.statusExpiry = time.Now().Add(4 * time.Second)
+			}
 		}
-		{
-			content, hdrLines := m.renderLocalContent()
-			m.localNewsHeaderLines = hdrLines
-			m.viewports[TabLocal].SetContent(content)
+		m.rerender(TabLocal)
+
+	case articleMsg:
+		if m.reader.open && msg.url == m.reader.item.URL {
+			m.reader.loading = false
+			if msg.err != nil {
+				m.reader.err = msg.err.Error()
+			} else {
+				m.reader.text = msg.text
+			}
+			m.reader.vp.SetContent(m.renderReaderContent())
 		}
 
+	case clipboardMsg:
+		if msg.err != nil {
+			m.statusMsg = "Copy failed: " + msg.err.Error()
+		} else {
+			m.statusMsg = "Copied " + msg.what + " to clipboard"
+		}
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+
+	case snapshotMsg:
+		switch {
+		case msg.err != nil:
+			m.statusMsg = "Snapshot failed: " + msg.err.Error()
+		case msg.copied:
+			m.statusMsg = "Snapshot copied to clipboard"
+		default:
+			m.statusMsg = "Snapshot saved to " + msg.path
+		}
+		m.statusExpiry = time.Now().Add(4 * time.Second)
+
 	case openURLMsg:
 		// No-op — the Cmd already ran xdg-open/open; nothing to update
 		_ = msg
@@ -618,28 +1023,70 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // ─── View ─────────────────────────────────────────────────────────────────────
 
-func (m Model) View() string {
+func (m Model) View() (out string) {
+	defer recoverRender("view", &out)
 	if m.width == 0 {
 		return "Initializing Watchtower..."
 	}
-	return lipgloss.JoinVertical(lipgloss.Left,
-		m.renderHeader(),
-		m.renderTabs(),
-		m.renderActivePane(),
-		m.renderFooter(),
-	)
+	if m.glance {
+		if m.confirmQuit {
+			return lipgloss.JoinVertical(lipgloss.Left, m.renderGlance(), m.renderFooter())
+		}
+		return m.renderGlance()
+	}
+	view := m.renderActivePane()
+	if !m.focus {
+		view = lipgloss.JoinVertical(lipgloss.Left,
+			m.renderHeader(),
+			m.renderTabs(),
+			view,
+			m.renderFooter(),
+		)
+	} else if m.confirmQuit {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, m.renderFooter())
+	}
+	// Center the capped layout on wide terminals
+	if m.termWidth > m.width {
+		view = lipgloss.PlaceHorizontal(m.termWidth, lipgloss.Center, view)
+	}
+	return view
+}
+
+// The smallest terminal the layout is computed for. Narrower or shorter
+// terminals get a clipped view rather than negative widths.
+const (
+	minLayoutWidth  = 40
+	minLayoutHeight = 12
+)
+
+// paneHeight is the height left for the active pane's content once the
+// header, tab bar, footer and pane border are drawn. Focus mode keeps only
+// the border.
+func (m Model) paneHeight() int {
+	if m.focus {
+		return m.height - 2
+	}
+	return m.height - 6
 }
 
 func (m Model) renderHeader() string {
+	if m.width < m.cfg.CompactHeaderWidth {
+		return m.renderCompactHeader()
+	}
 	isLoading := len(m.loading) > 0
 	loadStr := ""
-	if isLoading {
+	if done, total := m.refreshProgress(); done < total {
+		loadStr = "  " + m.spinner.View() + " " + progressBar(done, total, 8) + fmt.Sprintf(" %d/%d loaded", done, total)
+	} else if isLoading {
 		loadStr = "  " + m.spinner.View() + " loading..."
 	}
 	refreshStr := ""
 	if !m.lastRefresh.IsZero() {
 		refreshStr = fmt.Sprintf("  updated %s", m.lastRefresh.Format("15:04:05"))
 	}
+	if m.cfg.QuietHours.Active(time.Now()) {
+		refreshStr += "  paused (quiet hours)"
+	}
 	title := StyleTitle.Render("🌍 WATCHTOWER")
 	right := StyleSubtitle.Render("real-time intelligence" + loadStr + refreshStr)
 	gap := m.width - lipgloss.Width(title) - lipgloss.Width(right) - 4
@@ -651,10 +1098,35 @@ func (m Model) renderHeader() string {
 	)
 }
 
+// renderCompactHeader is the header for narrow terminals: a short title and
+// just the load progress or the last refresh time.
+func (m Model) renderCompactHeader() string {
+	status := ""
+	if done, total := m.refreshProgress(); done < total {
+		status = fmt.Sprintf("%s %d/%d", m.spinner.View(), done, total)
+	} else if len(m.loading) > 0 {
+		status = m.spinner.View()
+	} else if !m.lastRefresh.IsZero() {
+		status = "↻ " + m.lastRefresh.Format("15:04")
+	}
+	if m.cfg.QuietHours.Active(time.Now()) {
+		status += " ⏸"
+	}
+	title := StyleTitle.Render("🌍 WT")
+	right := StyleSubtitle.Render(status)
+	gap := m.width - lipgloss.Width(title) - lipgloss.Width(right) - 4
+	if gap < 1 {
+		gap = 1
+	}
+	return StyleHeader.Width(m.width).Render(
+		title + strings.Repeat(" ", gap) + right,
+	)
+}
+
 func (m Model) renderTabs() string {
-	names := []string{"1 Overview", "2 Global News", "3 Local"}
 	var parts []string
-	for i, name := range names {
+	for i, t := range m.tabs {
+		name := fmt.Sprintf("%d %s", i+1, t.name)
 		if i == m.activeTab {
 			parts = append(parts, StyleActiveTab.Render("[ "+name+" ]"))
 		} else {
@@ -665,28 +1137,35 @@ func (m Model) renderTabs() string {
 }
 
 func (m Model) renderActivePane() string {
-	contentH := m.height - 6
+	contentH := m.paneHeight()
 	if contentH < 5 {
 		contentH = 5
 	}
-	return StylePane.Width(m.width - 2).Height(contentH).Render(
-		m.viewports[m.activeTab].View(),
-	)
+	view := m.viewports[m.activeTab].View()
+	if m.reader.open {
+		view = m.reader.vp.View()
+	} else if m.diagnostics {
+		view = m.renderDiagnostics()
+	}
+	return StylePane.Width(m.width - 2).Height(contentH).Render(view)
 }
 
 func (m Model) renderFooter() string {
+	if m.confirmQuit {
+		return StyleFooterStatus.Width(m.width).Render("  Quit? (y/n)")
+	}
 	// Show status message if active (e.g. "Opening article...")
 	if m.statusMsg != "" && time.Now().Before(m.statusExpiry) {
 		return StyleFooterStatus.Width(m.width).Render("  ✓ " + m.statusMsg)
 	}
-	var hint string
-	switch m.activeTab {
-	case TabNews:
-		hint = "  jk navigate  enter open in browser  d/u page  g/G top/bottom  tab switch  r refresh  b brief  q quit"
-	case TabLocal:
-		hint = "  jk navigate  enter open in browser  d/u page  g/G top/bottom  tab switch  r refresh  i local brief  q quit"
-	default:
-		hint = "  ↑↓/jk scroll  tab/←→ switch  1 overview  2 news  3 local  r refresh  b brief  q quit"
+	hint := "  " + m.tabs[m.activeTab].hint(m)
+	if len(m.failedSections()) > 0 {
+		hint = "  x retry failed" + hint
+	}
+	if m.reader.open {
+		hint = "  jk scroll  d/u page  g/G top/bottom  o open in browser  c copy URL  esc close"
+	} else if m.diagnostics {
+		hint = "  D close diagnostics  r refresh  q quit"
 	}
 	return StyleFooter.Width(m.width).Render(hint)
 }
@@ -703,7 +1182,7 @@ func (m Model) renderOverviewContent() string {
 	halfW := innerW / 2
 
 	// Available inner height
-	contentH := m.height - 6
+	contentH := m.paneHeight()
 	if contentH < 10 {
 		contentH = 10
 	}
@@ -723,10 +1202,10 @@ func (m Model) renderOverviewContent() string {
 	qW := halfW - 3
 
 	// Render the four panels
-	topLeft := m.quadrantBox("🌤  WEATHER  "+m.cfg.Location.City, m.renderWeatherPanel(qW, topQH), halfW-1, topH)
-	topRight := m.quadrantBox("🧠  INTEL BRIEF", m.renderBriefPanel(qW, topQH), halfW-1, topH)
-	botLeft := m.quadrantBox("₿  MARKETS & PRICES", m.renderCryptoPanel(qW, botQH), halfW-1, botH)
-	botRight := m.quadrantBox("📊  PREDICTION MARKETS", m.renderPolyPanel(qW, botQH), halfW-1, botH)
+	topLeft := m.quadrantBox("🌤  WEATHER  "+m.cfg.Location.City+m.loadingMark("weather"), m.renderWeatherPanel(qW, topQH), halfW-1, topH)
+	topRight := m.quadrantBox("🧠  INTEL BRIEF"+m.loadingMark("brief"), m.renderBriefPanel(qW, topQH), halfW-1, topH)
+	botLeft := m.quadrantBox("₿  MARKETS & PRICES"+m.loadingMark("crypto", "stocks", "commodities"), m.renderCryptoPanel(qW, botQH), halfW-1, botH)
+	botRight := m.quadrantBox("📊  PREDICTION MARKETS"+m.loadingMark("poly"), m.renderPolyPanel(qW, botQH), halfW-1, botH)
 
 	topRow := lipgloss.JoinHorizontal(lipgloss.Top, topLeft, " ", topRight)
 	botRow := lipgloss.JoinHorizontal(lipgloss.Top, botLeft, " ", botRight)
@@ -755,13 +1234,27 @@ func (m Model) renderWeatherPanel(w, h int) string {
 
 	wc := m.weatherCond
 	// Large icon + temp on first line
-	sb.WriteString(fmt.Sprintf("%s  %s\n", wc.Icon,
+	sb.WriteString(fmt.Sprintf("%s  %s\n", m.icon(wc.Icon),
 		StyleWeatherTemp.Render(m.formatTemp(wc.TempC))))
-	sb.WriteString(StyleWeatherDesc.Render(wc.Description) + "\n")
+	sb.WriteString(StyleWeatherDesc.Render(m.tr(wc.Description)) + "\n")
 	sb.WriteString(StyleAge.Render(fmt.Sprintf("Feels like %s", m.formatTemp(wc.FeelsLikeC))) + "\n\n")
-	sb.WriteString(fmt.Sprintf("💧 %d%%   💨 %.0f km/h %s   ☀ UV %.0f\n",
-		wc.Humidity, wc.WindSpeedKmh,
-		weather.WindDirectionStr(wc.WindDirection), wc.UVIndex))
+	stats := fmt.Sprintf("%s %d%%   %s %s %s",
+		m.icon("💧"), wc.Humidity, m.icon("💨"), weather.FormatWind(wc.WindSpeedKmh, m.cfg.WindUnit),
+		weather.WindDirectionStr(wc.WindDirection))
+	// Not every provider reports UV; zero is left out rather than shown
+	if wc.UVIndex > 0 {
+		stats += fmt.Sprintf("   %s %.0f", m.icon("☀ UV"), wc.UVIndex)
+	}
+	sb.WriteString(stats + "\n")
+	adviceLines := 0
+	if astro := m.astroLine(); astro != "" {
+		sb.WriteString(StyleAge.MaxWidth(w).Render(astro) + "\n")
+		adviceLines++
+	}
+	if advice := m.weatherAdvice(); advice != "" {
+		sb.WriteString(StyleWeatherDesc.MaxWidth(w).Render(advice) + "\n")
+		adviceLines++
+	}
 
 	// Compact forecast — as many rows as fit
 	if len(m.forecast) > 0 {
@@ -769,7 +1262,7 @@ func (m Model) renderWeatherPanel(w, h int) string {
 		sb.WriteString(StyleTableHeader.Render(
 			fmt.Sprintf("%-10s  %-4s %5s %5s %5s", "Day", "", "Hi", "Lo", "Rain")) + "\n")
 		sb.WriteString(StyleDivider.Render(strings.Repeat("─", minInt(w, 36))) + "\n")
-		maxRows := h - 8
+		maxRows := h - 8 - adviceLines
 		if maxRows < 1 {
 			maxRows = 1
 		}
@@ -782,17 +1275,32 @@ func (m Model) renderWeatherPanel(w, h int) string {
 				dayLabel = "Today     "
 			}
 			sb.WriteString(fmt.Sprintf("%-10s  %s  %4s %4s %3.0fmm\n",
-				dayLabel, f.Icon, m.formatTemp(f.MaxTempC), m.formatTemp(f.MinTempC), f.RainMM))
+				dayLabel, m.icon(f.Icon), m.formatTemp(f.MaxTempC), m.formatTemp(f.MinTempC), f.RainMM))
 		}
 	}
 
 	return sb.String()
 }
 
+// briefStale reports whether a brief generated at t is in the last
+// brief_stale_percent of its cache lifetime (or past it), so the panel can
+// nudge a refresh before the cache quietly expires.
+func (m Model) briefStale(t time.Time) bool {
+	if m.cfg.LLMAPIKey == "" || m.cfg.BriefStalePct <= 0 || m.cfg.BriefCacheMins <= 0 {
+		return false
+	}
+	ttl := time.Duration(m.cfg.BriefCacheMins) * time.Minute
+	left := ttl - time.Since(t)
+	return left <= ttl*time.Duration(m.cfg.BriefStalePct)/100
+}
+
 func (m Model) renderBriefPanel(w, h int) string {
 	var sb strings.Builder
+	if stats := m.newsStats(m.newsItems()); stats != "" {
+		sb.WriteString(stats + "\n\n")
+	}
 
-	if m.cfg.LLMAPIKey == "" {
+	if m.cfg.LLMAPIKey == "" && m.brief == nil {
 		sb.WriteString(StyleWarning.Render("⚠  No LLM_API_KEY set.\n\n"))
 		sb.WriteString(StyleMuted.Render("Add key to:\n~/.config/watchtower/config.yaml\n\nSet llm_provider and llm_api_key.\n\nPress [b] after adding key."))
 		return sb.String()
@@ -805,9 +1313,12 @@ func (m Model) renderBriefPanel(w, h int) string {
 	}
 
 	if errMsg, ok := m.errors["brief"]; ok {
-		sb.WriteString(StyleError.Render("⚠ "+errMsg) + "\n\n")
-		sb.WriteString(StyleMuted.Render("Press [b] to retry."))
-		return sb.String()
+		sb.WriteString(StyleError.Width(w).Render("⚠ "+errMsg) + "\n")
+		if m.brief == nil {
+			sb.WriteString("\n" + StyleMuted.Render("Press [b] to retry."))
+			return sb.String()
+		}
+		sb.WriteString(StyleMuted.Render("Showing the last brief · [b] to retry") + "\n\n")
 	}
 
 	if m.brief == nil {
@@ -829,7 +1340,13 @@ func (m Model) renderBriefPanel(w, h int) string {
 			cacheAge = fmt.Sprintf("  cached %dm ago", mins)
 		}
 	}
-	sb.WriteString(StyleBriefMeta.Render(b.GeneratedAt.Format("15:04")+"  "+b.Model+cacheAge) + "\n\n")
+	sb.WriteString(StyleBriefMeta.Render(b.GeneratedAt.Format("15:04")+"  "+b.Model+cacheAge) + "\n")
+	if m.loading["briefRefresh"] {
+		sb.WriteString(StyleMuted.Render(m.spinner.View()+" refreshing in the background…") + "\n")
+	} else if m.briefStale(b.GeneratedAt) {
+		sb.WriteString(StyleWarning.Render("stale — press B to refresh") + "\n")
+	}
+	sb.WriteString("\n")
 
 	// Word-wrapped summary
 	wrapped := wordWrap(b.Summary, w-2)
@@ -837,12 +1354,14 @@ func (m Model) renderBriefPanel(w, h int) string {
 		sb.WriteString(line + "\n")
 	}
 
-	if len(b.KeyThreats) > 0 {
+	if len(b.KeyThreats) > 0 && m.cfg.BriefSections.Threats {
 		sb.WriteString("\n" + StyleBriefTitle.Render("KEY THREATS") + "\n")
+		maxLines := m.cfg.ThreatLines.Overview
+		if m.focus {
+			maxLines = m.cfg.ThreatLines.Focus
+		}
 		for _, t := range b.KeyThreats {
-			// Word-wrap each threat to panel width rather than truncating
-			wrapped := wordWrap("● "+t, w-2)
-			for i, line := range strings.Split(wrapped, "\n") {
+			for i, line := range threatLines(t, w-2, maxLines) {
 				if i == 0 {
 					sb.WriteString(StyleThreatItem.Render(line) + "\n")
 				} else {
@@ -853,9 +1372,26 @@ func (m Model) renderBriefPanel(w, h int) string {
 		}
 	}
 
+	if m.cfg.LLMAPIKey == "" {
+		sb.WriteString("\n" + StyleMuted.Render("Set llm_api_key in config.yaml for an AI brief.") + "\n")
+	}
+
 	return sb.String()
 }
 
+// threatLines word-wraps a key threat to w columns behind its bullet. With
+// maxLines > 0 the threat is cut to that many lines, ending in "…".
+func threatLines(t string, w, maxLines int) []string {
+	lines := strings.Split(wordWrap("● "+t, w), "\n")
+	if maxLines <= 0 || len(lines) <= maxLines {
+		return lines
+	}
+	// The rest never fits on one line (or wordWrap would have joined it),
+	// so truncate always ends it with "…"
+	lines[maxLines-1] = truncate(strings.Join(lines[maxLines-1:], " "), w)
+	return lines[:maxLines]
+}
+
 func (m Model) renderCryptoPanel(w, h int) string {
 	var sb strings.Builder
 
@@ -877,10 +1413,9 @@ func (m Model) renderCryptoPanel(w, h int) string {
 		sb.WriteString(StyleTableHeader.Render(hdr) + "\n")
 		sb.WriteString(StyleDivider.Render(strings.Repeat("─", minInt(w-1, 55))) + "\n")
 		for _, p := range m.cryptoPrices {
-			chStyle := StylePositive
+			chStyle := m.changeStyle(p.Change24h)
 			chIcon := "▲"
 			if p.Change24h < 0 {
-				chStyle = StyleNegative
 				chIcon = "▼"
 			}
 			name := p.Name
@@ -899,7 +1434,7 @@ func (m Model) renderCryptoPanel(w, h int) string {
 	sb.WriteString("\n")
 
 	// ── Stock Indices ─────────────────────────────────────────────────────────
-	sb.WriteString(StyleSubSectionHeader.Render(" INDICES") + "\n")
+	sb.WriteString(StyleSubSectionHeader.Render(" INDICES") + staleMark(anyStale(m.quoteTimes("stocks"), m.staleAfter())) + "\n")
 	if errMsg, ok := m.errors["stocks"]; ok {
 		sb.WriteString(StyleError.Render("⚠ "+errMsg) + "\n")
 	} else if len(m.stockIndices) == 0 {
@@ -910,10 +1445,9 @@ func (m Model) renderCryptoPanel(w, h int) string {
 			nameW = 6
 		}
 		for _, idx := range m.stockIndices {
-			chStyle := StylePositive
+			chStyle := m.changeStyle(idx.ChangePct)
 			chIcon := "▲"
 			if idx.ChangePct < 0 {
-				chStyle = StyleNegative
 				chIcon = "▼"
 			}
 			name := idx.Name
@@ -922,7 +1456,7 @@ func (m Model) renderCryptoPanel(w, h int) string {
 			}
 			sb.WriteString(fmt.Sprintf("%-*s %11s %s\n",
 				nameW, name,
-				markets.FormatPrice(idx.Price),
+				markets.FormatLevel(idx.Price),
 				chStyle.Render(fmt.Sprintf("%s%5.2f%%", chIcon, idx.ChangePct)),
 			))
 		}
@@ -931,7 +1465,7 @@ func (m Model) renderCryptoPanel(w, h int) string {
 	sb.WriteString("\n")
 
 	// ── Commodities ───────────────────────────────────────────────────────────
-	sb.WriteString(StyleSubSectionHeader.Render(" COMMODITIES") + "\n")
+	sb.WriteString(StyleSubSectionHeader.Render(" COMMODITIES") + staleMark(anyStale(m.quoteTimes("commodities"), m.staleAfter())) + "\n")
 	if errMsg, ok := m.errors["commodities"]; ok {
 		sb.WriteString(StyleError.Render("⚠ "+errMsg) + "\n")
 	} else if len(m.commodities) == 0 {
@@ -942,20 +1476,19 @@ func (m Model) renderCryptoPanel(w, h int) string {
 			nameW = 6
 		}
 		for _, c := range m.commodities {
-			chStyle := StylePositive
+			chStyle := m.changeStyle(c.ChangePct)
 			chIcon := "▲"
 			if c.ChangePct < 0 {
-				chStyle = StyleNegative
 				chIcon = "▼"
 			}
 			name := c.Name
 			if len(name) > nameW {
 				name = name[:nameW-1] + "…"
 			}
-			unitStr := StyleMuted.Render(fmt.Sprintf("%-6s", c.Unit))
+			unitStr := StyleMuted.Render(fmt.Sprintf("%-6s", c.UnitLabel()))
 			sb.WriteString(fmt.Sprintf("%-*s %9s %s %s\n",
 				nameW, name,
-				markets.FormatPrice(c.Price),
+				markets.FormatPriceIn(c.Price, c.Currency),
 				unitStr,
 				chStyle.Render(fmt.Sprintf("%s%5.2f%%", chIcon, c.ChangePct)),
 			))
@@ -995,7 +1528,10 @@ func (m Model) renderPolyPanel(w, h int) string {
 		}
 		pct := pm.Probability * 100
 		pctStyle := StyleNeutral
+		pctStr := fmt.Sprintf("%5.1f%%", pct)
 		switch {
+		case !pm.HasProbability:
+			pctStr = fmt.Sprintf("%6s", "—")
 		case pct >= 66:
 			pctStyle = StylePositive
 		case pct <= 33:
@@ -1011,7 +1547,7 @@ func (m Model) renderPolyPanel(w, h int) string {
 		}
 		sb.WriteString(fmt.Sprintf("%-*s %s  %5s\n",
 			titleW, title,
-			pctStyle.Render(fmt.Sprintf("%5.1f%%", pct)),
+			pctStyle.Render(pctStr),
 			endDate,
 		))
 	}
@@ -1028,7 +1564,8 @@ func (m Model) renderNewsContent() (string, int) {
 	if errMsg, ok := m.errors["global"]; ok {
 		sb.WriteString(StyleError.Render("⚠ Error: "+errMsg) + "\n\n")
 	}
-	if len(m.globalNews) == 0 {
+	news := m.newsItems()
+	if len(news) == 0 {
 		if m.loading["global"] {
 			return "  " + m.spinner.View() + " Fetching global news...", 0
 		}
@@ -1038,19 +1575,38 @@ func (m Model) renderNewsContent() (string, int) {
 	// ── Top header: country risk panel spanning full width ────────────────
 	innerW := m.width - 6 // account for pane borders/padding
 
-	header, countryRiskLines := m.renderCountryRiskPanel(innerW)
-	divider := StyleDivider.Render(strings.Repeat("─", innerW))
+	news = m.filteredNews()
 	sectionHdr := StyleSectionHeader.Render(
-		fmt.Sprintf(" ARTICLES  (%d)  ·  j/k navigate  ·  enter to open in browser", len(m.globalNews)))
+		fmt.Sprintf(" ARTICLES  (%d)  ·  j/k navigate  ·  %s", len(news), m.enterLabel())) + m.loadingMark("global", "alerts")
+	if m.compare {
+		sectionHdr = StyleSectionHeader.Render(
+			fmt.Sprintf(" COMPARE SOURCES  (%d stories)  ·  j/k navigate  ·  %s  ·  m back to all", len(m.newsClusters), m.enterLabel())) + m.loadingMark("global", "alerts")
+	}
+	switch m.relevanceMode() {
+	case "boost":
+		sectionHdr += StyleMuted.Render("  ·  relevant to you first")
+	case "only":
+		sectionHdr += StyleMuted.Render("  ·  only relevant to you")
+	}
+	if m.countryFilter != "" {
+		sectionHdr += StyleWarning.Render("  ·  only " + m.countryFilter + " (F to clear)")
+	}
 
-	// Header lines = country risk panel lines + divider + section header + blank lines
-	// header + "\n" + divider + "\n\n" + sectionHdr + "\n\n"
-	// = countryRiskLines + 1 + 3 + 3 = countryRiskLines + 7
-	hdrLines := countryRiskLines + 7
+	// Section header + blank line when the risk panel is disabled
+	hdrLines := 2
+	if m.cfg.BriefSections.CountryRisks && m.cfg.NewsRiskPanel {
+		header, countryRiskLines := m.renderCountryRiskPanel(innerW)
+		divider := StyleDivider.Render(strings.Repeat("─", innerW))
 
-	sb.WriteString(header)
-	sb.WriteString("\n")
-	sb.WriteString(divider + "\n\n")
+		// Header lines = country risk panel lines + divider + section header + blank lines
+		// header + "\n" + divider + "\n\n" + sectionHdr + "\n\n"
+		// = countryRiskLines + 1 + 3 + 3 = countryRiskLines + 7
+		hdrLines = countryRiskLines + 7
+
+		sb.WriteString(header)
+		sb.WriteString("\n")
+		sb.WriteString(divider + "\n\n")
+	}
 	sb.WriteString(sectionHdr + "\n\n")
 
 	// Calculate available width for title line
@@ -1061,20 +1617,24 @@ func (m Model) renderNewsContent() (string, int) {
 		titleW = 20
 	}
 
-	for i, item := range m.globalNews {
+	if m.compare {
+		sb.WriteString(m.renderCompareRows(innerW))
+		news = nil
+	} else if len(news) == 0 && m.countryFilter != "" {
+		sb.WriteString("  " + StyleMuted.Render("No headlines mention "+m.countryFilter+".") + "\n")
+	} else if len(news) == 0 {
+		sb.WriteString("  " + StyleMuted.Render("No headlines mention your location or relevance keywords.") + "\n")
+	}
+	for i, item := range news {
 		if i >= 200 {
 			break
 		}
-		badge := threatStyle(item.ThreatLevel).Render(fmt.Sprintf(" %-8s", item.ThreatLevel.String()))
-		source := StyleSource.Render(item.Source)
-		age := StyleAge.Render(formatAge(item.Published))
+		badge := m.threatBadge(item, 8)
+		source := m.pinMark(item) + m.sourceLabel(item)
+		age := ageStyle(item.Published).Render(formatAge(item.Published))
 
 		// Truncate title to fit exactly one line
-		titleLine := item.Title
-		if len(titleLine) > titleW {
-			runes := []rune(titleLine)
-			titleLine = string(runes[:titleW-1]) + "…"
-		}
+		titleLine := truncate(item.Title, titleW)
 		urlIndicator := ""
 		if item.URL != "" {
 			urlIndicator = StyleMuted.Render("  ↗")
@@ -1086,26 +1646,98 @@ func (m Model) renderNewsContent() (string, int) {
 			line1 := fmt.Sprintf("%s %s  %s%s", badge, source, age, urlIndicator)
 			line2 := "  " + titleStyled
 			sb.WriteString(StyleSelectedRow.Render(line1) + "\n")
-			sb.WriteString(StyleSelectedRow.Render(line2) + "\n\n")
+			sb.WriteString(StyleSelectedRow.Render(line2) + "\n")
+			sb.WriteString(m.renderDescription(item, innerW) + "\n")
 		} else {
 			sb.WriteString(fmt.Sprintf("%s %s  %s%s\n  %s\n\n",
 				badge, source, age, urlIndicator,
 				StyleNewsTitle.Render(titleLine)))
 		}
 	}
+	if errMsg, ok := m.errors["alerts"]; ok {
+		sb.WriteString(StyleWarning.Render("⚠ alerts feed: "+errMsg) + "\n")
+	}
+	sb.WriteString(m.renderFailedSources("global", innerW))
 	return sb.String(), hdrLines
 }
 
+// renderCompareRows renders the compare view: each story covered by several
+// sources as a bracketed group of that story's headlines, one per source.
+// Rows keep the three-line height of the article list so selection and
+// scrolling work the same.
+func (m Model) renderCompareRows(w int) string {
+	if len(m.newsClusters) == 0 {
+		return "  " + StyleMuted.Render("No story is covered by more than one source yet.") + "\n"
+	}
+	var sb strings.Builder
+	titleW := maxInt(w-41, 20)
+	i := 0
+	for _, cluster := range m.newsClusters {
+		for j, item := range cluster {
+			// A bracket down the left edge ties the story's headlines together
+			gutter, titleGutter := StyleDivider.Render("│ "), StyleDivider.Render("│ ")
+			if j == 0 {
+				gutter = StyleDivider.Render("┌ ")
+			}
+			if j == len(cluster)-1 {
+				titleGutter = StyleDivider.Render("└ ")
+			}
+			meta := fmt.Sprintf("%s %s  %s", m.threatBadge(item, 8), m.sourceLabel(item),
+				ageStyle(item.Published).Render(formatAge(item.Published)))
+			if item.URL != "" {
+				meta += StyleMuted.Render("  ↗")
+			}
+			if j == 0 {
+				meta += StyleMuted.Render(fmt.Sprintf("  ·  %d sources", feeds.ClusterSources(cluster)))
+			}
+			title := truncate(item.Title, titleW)
+			if i == m.selectedNewsIdx {
+				sb.WriteString(gutter + StyleSelectedRow.Render(meta) + "\n")
+				sb.WriteString(titleGutter + StyleSelectedRow.Render("  "+StyleSelectedTitle.Render(title)) + "\n")
+				sb.WriteString(m.renderDescription(item, w))
+			} else {
+				sb.WriteString(gutter + meta + "\n")
+				sb.WriteString(titleGutter + "  " + StyleNewsTitle.Render(title) + "\n")
+			}
+			if j < len(cluster)-1 {
+				sb.WriteString(StyleDivider.Render("│") + "\n")
+			} else {
+				sb.WriteString("\n")
+			}
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// renderFailedSources lists the feed sources that contributed nothing on
+// the last fetch, so a broken source doesn't silently look healthy.
+func (m Model) renderFailedSources(key string, w int) string {
+	failed := m.failedSources[key]
+	if len(failed) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(StyleWarning.Render(fmt.Sprintf("⚠ %d source(s) failed on last refresh", len(failed))) + "\n")
+	for _, f := range failed {
+		sb.WriteString(StyleMuted.MaxWidth(w).Render("  "+f.Error()) + "\n")
+	}
+	return sb.String()
+}
+
 func (m Model) renderCountryRiskPanel(w int) (string, int) {
 	var sb strings.Builder
-	sb.WriteString(StyleBriefTitle.Render("🌡  COUNTRY RISK INDEX") + "\n")
+	sb.WriteString(StyleBriefTitle.Render("🌡  COUNTRY RISK INDEX") + m.loadingMark("risks") +
+		StyleMuted.Render("  ·  by "+m.riskOrder.String()+" (O)  ·  [/] select") + "\n")
 	sb.WriteString(StyleDivider.Render(strings.Repeat("─", minInt(w, 120))) + "\n")
 
 	if m.brief == nil || len(m.brief.CountryRisks) == 0 {
 		if m.loading["brief"] {
 			sb.WriteString("  " + m.spinner.View() + " Computing risks...\n")
+		} else if m.cfg.LLMAPIKey == "" {
+			sb.WriteString(StyleMuted.Render("  Country risk scores need an AI brief — set llm_api_key in config.yaml.") + "\n")
 		} else {
-			sb.WriteString(StyleMuted.Render("  Press [b] to generate risk scores.") + "\n")
+			sb.WriteString(StyleMuted.Render("  Press [b] to generate risk scores, or [C] to re-score just the risks.") + "\n")
 		}
 		return sb.String(), strings.Count(sb.String(), "\n")
 	}
@@ -1116,28 +1748,39 @@ func (m Model) renderCountryRiskPanel(w int) (string, int) {
 		barW   = 14 // progress bar characters
 		gapW   = 2  // spacing between columns
 	)
+	// Sorting by change also shows it, in a column taken from the name
+	deltaW := 0
+	if m.riskOrder == riskByDelta {
+		deltaW = 4 + gapW
+	}
 	// nameW: remaining space after score + bar + gaps + leading indent(2)
-	nameW := w - scoreW - barW - gapW*3 - 2
+	nameW := w - scoreW - barW - gapW*3 - 2 - deltaW
 	if nameW < 8 {
 		nameW = 8
 	}
 	// reasonW: same as nameW but offset past score col
-	reasonW := nameW + scoreW + gapW
+	reasonW := nameW + deltaW + scoreW + gapW
 
-	risks := m.brief.CountryRisks
+	risks := m.sortedRisks()
 
-	// Lay countries out in two columns if width allows (>=100 chars)
+	// Lay countries out in two columns when the width allows (>=100 chars),
+	// there are enough of them to balance, and every reason still fits in
+	// two wrapped lines of the narrower column
 	cols := 1
 	colW := w
-	if w >= 100 {
-		cols = 2
-		colW = w / 2
-		// Recalc nameW per column
-		nameW = colW - scoreW - barW - gapW*3 - 2
-		if nameW < 8 {
-			nameW = 8
+	if w >= 100 && len(risks) >= 4 {
+		halfNameW := max(w/2-scoreW-barW-gapW*3-2-deltaW, 8)
+		halfReasonW := halfNameW + deltaW + scoreW + gapW
+		longest := 0
+		for _, cr := range risks {
+			longest = max(longest, len([]rune(cr.Reason)))
+		}
+		if longest <= 2*halfReasonW-2 {
+			cols = 2
+			colW = w / 2
+			nameW = halfNameW
+			reasonW = halfReasonW
 		}
-		reasonW = nameW + scoreW + gapW
 	}
 
 	// Build each row as a plain string (styled pieces joined, then padded to colW)
@@ -1179,18 +1822,26 @@ func (m Model) renderCountryRiskPanel(w int) (string, int) {
 			// Pad with spaces to nameW so columns align
 			country = country + strings.Repeat(" ", nameW-len(runes))
 		}
+		// The row the news is filtered to ([/]) is highlighted
+		if cr.Country == m.countryFilter {
+			country = StyleSelectedTitle.Render(country)
+		}
 
-		// Reason: truncate to reasonW plain chars
-		reason := cr.Reason
-		reasonRunes := []rune(reason)
-		if len(reasonRunes) > reasonW {
-			reasonRunes = reasonRunes[:reasonW-3]
-			reason = string(reasonRunes) + "..."
+		// Reason: wrap to at most two lines of reasonW plain chars
+		reasonLines := strings.Split(wordWrap(cr.Reason, reasonW), "\n")
+		if len(reasonLines) > 2 {
+			reasonLines = reasonLines[:2]
+			reasonLines[1] = truncate(reasonLines[1]+" …", reasonW)
 		}
 
-		line1 := "  " + country + "  " + scoreStr + "  " + bar
-		line2 := "  " + StyleMuted.Render(reason)
-		return line1 + "\n" + line2
+		row := "  " + country + "  " + scoreStr + "  " + bar
+		if deltaW > 0 {
+			row = "  " + country + "  " + formatRiskDelta(m.riskDelta(cr)) + "  " + scoreStr + "  " + bar
+		}
+		for _, rl := range reasonLines {
+			row += "\n  " + StyleMuted.Render(rl)
+		}
+		return row
 	}
 
 	if cols == 1 {
@@ -1209,8 +1860,13 @@ func (m Model) renderCountryRiskPanel(w int) (string, int) {
 				right = strings.Split(r, "\n")
 			}
 
-			// Pad left lines to colW visible chars and join with right
-			for li, ll := range leftLines {
+			// Pad left lines to colW visible chars and join with right;
+			// rows differ in height when a reason wraps
+			for li := 0; li < max(len(leftLines), len(right)); li++ {
+				ll := ""
+				if li < len(leftLines) {
+					ll = leftLines[li]
+				}
 				// Measure visible width (strip ANSI for measurement)
 				visW := lipgloss.Width(ll)
 				padding := ""
@@ -1231,8 +1887,12 @@ func (m Model) renderCountryRiskPanel(w int) (string, int) {
 
 func (m Model) renderLocalBriefPanel(w int) string {
 	var sb strings.Builder
+	key, name := m.localBriefKey(), "local brief"
+	if m.safetyMode() {
+		name = "safety brief"
+	}
 
-	sb.WriteString(StyleSectionHeader.Render(" LOCAL BRIEF") + "\n\n")
+	sb.WriteString(StyleSectionHeader.Render(" "+strings.ToUpper(name)) + "\n\n")
 
 	if m.cfg.LLMAPIKey == "" {
 		sb.WriteString(StyleWarning.Render("⚠  No LLM_API_KEY set.\n"))
@@ -1241,28 +1901,28 @@ func (m Model) renderLocalBriefPanel(w int) string {
 		return sb.String()
 	}
 
-	if m.loading["localBrief"] {
-		sb.WriteString(m.spinner.View() + " Generating local brief...\n\n")
+	if m.loading[key] {
+		sb.WriteString(m.spinner.View() + " Generating " + name + "...\n\n")
 		sb.WriteString(StyleMuted.Render("Calling " + m.cfg.LLMProvider + "..."))
 		return sb.String()
 	}
 
-	if errMsg, ok := m.errors["localBrief"]; ok {
+	if errMsg, ok := m.errors[key]; ok {
 		sb.WriteString(StyleError.Render("⚠ "+errMsg) + "\n\n")
 		sb.WriteString(StyleMuted.Render("Press [i] to retry."))
 		return sb.String()
 	}
 
-	if m.localBrief == nil {
+	b := m.shownLocalBrief()
+	if b == nil {
 		if len(m.localNews) == 0 && m.weatherCond == nil {
 			sb.WriteString(StyleMuted.Render("Waiting for news and weather to load..."))
 		} else {
-			sb.WriteString(StyleMuted.Render("Press [i] to generate local brief."))
+			sb.WriteString(StyleMuted.Render("Press [i] to generate " + name + "."))
 		}
 		return sb.String()
 	}
 
-	b := m.localBrief
 	cacheAge := ""
 	if time.Since(b.GeneratedAt) > time.Minute {
 		mins := int(time.Since(b.GeneratedAt).Minutes())
@@ -1272,16 +1932,66 @@ func (m Model) renderLocalBriefPanel(w int) string {
 			cacheAge = fmt.Sprintf("  (cached %dm ago)", mins)
 		}
 	}
-	sb.WriteString(StyleBriefMeta.Render(b.GeneratedAt.Format("15:04")+"  "+b.Model+cacheAge) + "\n\n")
+	sb.WriteString(StyleBriefMeta.Render(b.GeneratedAt.Format("15:04")+"  "+b.Model+cacheAge) + "\n")
+	if m.briefStale(b.GeneratedAt) {
+		sb.WriteString(StyleWarning.Render("stale — press I to refresh") + "\n")
+	}
+	sb.WriteString("\n")
 
 	wrapped := wordWrap(b.Summary, w-2)
 	for _, line := range strings.Split(wrapped, "\n") {
 		sb.WriteString(line + "\n")
 	}
+	if len(b.Actions) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, a := range b.Actions {
+		lines := strings.Split(wordWrap(a, w-4), "\n")
+		for i, line := range lines {
+			bullet := "  "
+			if i == 0 {
+				bullet = StyleWarning.Render("•") + " "
+			}
+			sb.WriteString(bullet + line + "\n")
+		}
+	}
 
 	return sb.String()
 }
 
+// safetyMode reports whether the Local tab shows the safety brief
+func (m Model) safetyMode() bool {
+	return m.cfg.LocalBriefMode == "safety"
+}
+
+// localBriefKey is the m.loading / m.errors key of a local brief variant
+func localBriefKey(safety bool) string {
+	if safety {
+		return "safetyBrief"
+	}
+	return "localBrief"
+}
+
+// localBriefKey is the loading / error key of the brief the Local tab shows
+func (m Model) localBriefKey() string {
+	return localBriefKey(m.safetyMode())
+}
+
+// shownLocalBrief is the brief of the current local_brief_mode, or nil
+func (m Model) shownLocalBrief() *intel.LocalBrief {
+	if m.safetyMode() {
+		return m.safetyBrief
+	}
+	return m.localBrief
+}
+
+// requestLocalBrief marks the current mode's brief as loading and fetches
+// it, from the cache unless force is set
+func (m Model) requestLocalBrief(force bool) tea.Cmd {
+	m.loading[m.localBriefKey()] = true
+	return fetchLocalBrief(LLMConfig(m.cfg), m.cfg.Location.City, m.localNews, m.weatherCond, m.forecast, m.cfg.BriefCacheMins, m.safetyMode(), force)
+}
+
 func (m Model) renderLocalContent() (string, int) {
 	var sb strings.Builder
 
@@ -1289,20 +1999,33 @@ func (m Model) renderLocalContent() (string, int) {
 	weatherBlock := ""
 	if m.weatherCond != nil {
 		wc := m.weatherCond
-		weatherBlock += StyleSectionHeader.Render(" WEATHER  "+wc.City) + "\n\n"
+		weatherBlock += StyleSectionHeader.Render(" WEATHER  "+wc.City) + m.loadingMark("weather") + "\n\n"
 		weatherBlock += fmt.Sprintf("  %s  %s  %s  (feels like %s)\n",
-			wc.Icon, wc.Description, m.formatTemp(wc.TempC), m.formatTemp(wc.FeelsLikeC))
-		weatherBlock += fmt.Sprintf("  💧 Humidity: %d%%   💨 Wind: %.0f km/h %s   👁 Visibility: %.0f km   ☀ UV: %.0f\n\n",
-			wc.Humidity, wc.WindSpeedKmh,
-			weather.WindDirectionStr(wc.WindDirection),
-			wc.Visibility/1000, wc.UVIndex)
+			m.icon(wc.Icon), m.tr(wc.Description), m.formatTemp(wc.TempC), m.formatTemp(wc.FeelsLikeC))
+		weatherBlock += fmt.Sprintf("  %sHumidity: %d%%   %sWind: %s %s",
+			m.glyph("💧"), wc.Humidity, m.glyph("💨"), weather.FormatWind(wc.WindSpeedKmh, m.cfg.WindUnit),
+			weather.WindDirectionStr(wc.WindDirection))
+		// Fields the provider didn't report are left out
+		if wc.Visibility > 0 {
+			weatherBlock += fmt.Sprintf("   %sVisibility: %s", m.glyph("👁"), weather.FormatVisibility(wc.Visibility, m.cfg.VisibilityUnit))
+		}
+		if wc.UVIndex > 0 {
+			weatherBlock += fmt.Sprintf("   %sUV: %.0f", m.glyph("☀"), wc.UVIndex)
+		}
+		weatherBlock += "\n\n"
+		if astro := m.astroLine(); astro != "" {
+			weatherBlock += "  " + StyleAge.Render(astro) + "\n\n"
+		}
+		if advice := m.weatherAdvice(); advice != "" {
+			weatherBlock += "  " + StyleWeatherDesc.Render(advice) + "\n\n"
+		}
 		if len(m.forecast) > 0 {
 			weatherBlock += StyleTableHeader.Render(
 				fmt.Sprintf("  %-12s %-16s %8s %8s %10s", "DATE", "CONDITION", "MAX", "MIN", "RAIN")) + "\n"
 			weatherBlock += StyleDivider.Render(strings.Repeat("─", 60)) + "\n"
 			for _, f := range m.forecast {
-				weatherBlock += fmt.Sprintf("  %-12s %s %-12s %6s %6s %7.1fmm\n",
-					f.Date.Format("Mon Jan 02"), f.Icon, f.Desc,
+				weatherBlock += fmt.Sprintf("  %-12s %s %s %6s %6s %7.1fmm\n",
+					f.Date.Format("Mon Jan 02"), m.icon(f.Icon), padRight(m.tr(f.Desc), 12),
 					m.formatTemp(f.MaxTempC), m.formatTemp(f.MinTempC), f.RainMM)
 			}
 			weatherBlock += "\n"
@@ -1330,7 +2053,10 @@ func (m Model) renderLocalContent() (string, int) {
 	sb.WriteString("\n")
 	sb.WriteString(localBriefBlock)
 	sb.WriteString("\n")
-	localHdr := StyleSectionHeader.Render(" LOCAL NEWS  " + m.cfg.Location.City)
+	localHdr := StyleSectionHeader.Render(" LOCAL NEWS  "+m.cfg.Location.City) + m.loadingMark("local")
+	if stats := m.newsStats(m.localItems()); stats != "" {
+		localHdr += "  " + stats
+	}
 	sb.WriteString(localHdr + "\n\n")
 	hdrLines += strings.Count(localHdr+"\n\n", "\n")
 
@@ -1341,16 +2067,24 @@ func (m Model) renderLocalContent() (string, int) {
 		sb.WriteString("  No local news loaded. Press r to refresh.\n")
 		hdrLines += strings.Count("  No local news loaded. Press r to refresh.\n", "\n")
 	} else {
-		sectionHdr := fmt.Sprintf(" ARTICLES  (%d)  ·  j/k navigate  ·  enter to open in browser", len(m.localNews))
+		local := m.localItems()
+		sectionHdr := fmt.Sprintf(" ARTICLES  (%d)  ·  j/k navigate  ·  %s", len(local), m.enterLabel())
+		if hidden := len(m.localNews) - len(local); hidden > 0 {
+			sectionHdr += fmt.Sprintf("  ·  %d also in Global hidden", hidden)
+		}
 		sb.WriteString(StyleSectionHeader.Render(sectionHdr) + "\n\n")
 		hdrLines += strings.Count(StyleSectionHeader.Render(sectionHdr)+"\n\n", "\n")
 
-		for i, item := range m.localNews {
+		var global map[string]bool
+		if m.cfg.CrossDedup == "dim" {
+			global = m.globalStoryKeys()
+		}
+		for i, item := range local {
 			if i >= 100 {
 				break
 			}
-			badge := threatStyle(item.ThreatLevel).Render(fmt.Sprintf(" %-6s", item.ThreatLevel.String()))
-			age := StyleAge.Render(formatAge(item.Published))
+			badge := m.threatBadge(item, 6)
+			age := m.pinMark(item) + ageStyle(item.Published).Render(formatAge(item.Published))
 			urlIndicator := ""
 			if item.URL != "" {
 				urlIndicator = StyleMuted.Render("  ↗")
@@ -1361,31 +2095,99 @@ func (m Model) renderLocalContent() (string, int) {
 				line1 := badge + " " + age + urlIndicator
 				line2 := "  " + StyleSelectedTitle.Render(titleLine)
 				sb.WriteString(StyleSelectedRow.Render(line1) + "\n")
-				sb.WriteString(StyleSelectedRow.Render(line2) + "\n\n")
+				sb.WriteString(StyleSelectedRow.Render(line2) + "\n")
+				sb.WriteString(m.renderDescription(item, innerW) + "\n")
+			} else if global[feeds.StoryKey(item.Title)] {
+				sb.WriteString(fmt.Sprintf("%s %s %s%s\n  %s\n\n",
+					badge, age, urlIndicator, StyleMuted.Render("  also in Global"),
+					StyleMuted.Render(item.Title)))
 			} else {
 				sb.WriteString(fmt.Sprintf("%s %s %s\n  %s\n\n",
 					badge, age, urlIndicator,
 					StyleNewsTitle.Render(item.Title)))
 			}
 		}
+		sb.WriteString(m.renderFailedSources("local", innerW))
 	}
 	return sb.String(), hdrLines
 }
 
-// scrollNewsToSelected adjusts the news viewport so the selected article stays visible.
-// Each article is exactly 3 lines. Called after selectedNewsIdx or content changes.
-// vp is a pointer to m.viewports[TabNews] from the calling Update copy.
-func scrollNewsIntoView(vp *viewport.Model, headerLines, selectedIdx int) {
-	// When selectedIdx is 0, show the country risk panel at the top
-	if selectedIdx == 0 {
-		vp.GotoTop() // Use built-in method instead of SetYOffset(0)
+// renderDescription renders the expanded summary under the selected
+// article: up to description_lines word-wrapped lines, or nothing when
+// collapsed.
+func (m Model) renderDescription(item feeds.NewsItem, w int) string {
+	if !m.expanded {
+		return ""
+	}
+	if item.Description == "" {
+		return "    " + StyleMuted.Render("No summary in the feed for this article") + "\n"
+	}
+	lines := strings.Split(wordWrap(item.Description, maxInt(w-6, 20)), "\n")
+	if len(lines) > m.cfg.DescriptionLines {
+		lines = lines[:m.cfg.DescriptionLines]
+		lines[len(lines)-1] += " …"
+	}
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString("    " + StyleDescription.Render(line) + "\n")
+	}
+	return sb.String()
+}
+
+// rerender refreshes a tab's viewport content from the current model state,
+// keeping the header line counts used for scroll tracking in sync.
+//
+// Until the first WindowSizeMsg there is no width to lay out against, so
+// nothing is rendered; the size handler renders every tab once it arrives.
+func (m *Model) rerender(tab int) {
+	if m.width == 0 {
 		return
 	}
-	const linesPerItem = 3
+	content, hdrLines := m.renderTab(tab)
+	m.headerLines[tab] = hdrLines
+	m.viewports[tab].SetContent(content)
+}
+
+// renderTab renders one tab's content, recovering from a panic in its
+// renderer so one bad panel doesn't take down the dashboard
+func (m Model) renderTab(tab int) (content string, hdrLines int) {
+	defer recoverRender(m.tabs[tab].name, &content)
+	return m.tabs[tab].render(m)
+}
+
+// anyLoading reports whether any of the given sections has a fetch in flight
+func (m Model) anyLoading(keys ...string) bool {
+	for _, k := range keys {
+		if m.loading[k] {
+			return true
+		}
+	}
+	return false
+}
+
+// loadingMark returns a spinner suffix for section titles while any of the
+// given sections is refreshing, and "" otherwise.
+func (m Model) loadingMark(keys ...string) string {
+	if !m.anyLoading(keys...) {
+		return ""
+	}
+	return "  " + m.spinner.View()
+}
+
+// scrollNewsToSelected adjusts a list viewport so the selected row stays visible.
+// Each row is linesPerItem lines (3 for articles). Called after the selection or content changes.
+// vp is a pointer to the list tab's viewport from the calling Update copy.
+func scrollNewsIntoView(vp *viewport.Model, headerLines, selectedIdx, linesPerItem int) {
 	vpH := vp.Height
 	if vpH <= 0 {
 		return
 	}
+	// When the first row fits below the header, show the header too (e.g.
+	// the country risk panel at the top of the news tab)
+	if selectedIdx == 0 && headerLines+linesPerItem <= vpH {
+		vp.GotoTop() // Use built-in method instead of SetYOffset(0)
+		return
+	}
 	itemLine := headerLines + selectedIdx*linesPerItem
 	if itemLine < 0 {
 		itemLine = 0
@@ -1407,58 +2209,194 @@ func scrollNewsIntoView(vp *viewport.Model, headerLines, selectedIdx int) {
 
 // ─── Tea commands ─────────────────────────────────────────────────────────────
 
-func fetchGlobalNews() tea.Cmd {
-	return func() tea.Msg {
-		items, err := feeds.FetchGlobalNews(context.Background())
-		return globalNewsMsg{items, err}
+// LLMConfig maps the llm_* settings, fallbacks included, to intel.LLMConfig
+func LLMConfig(cfg *config.Config) intel.LLMConfig {
+	llm := intel.LLMConfig{Provider: intel.Provider(cfg.LLMProvider), APIKey: cfg.LLMAPIKey, Model: cfg.LLMModel, Gateway: gateway(cfg, cfg.LLMProvider)}
+	llm.Azure = azure(cfg)
+	for _, f := range cfg.LLMFallbacks {
+		llm.Fallbacks = append(llm.Fallbacks, intel.LLMConfig{Provider: intel.Provider(f.Provider), APIKey: f.APIKey, Model: f.Model, Gateway: gateway(cfg, f.Provider), Azure: azure(cfg)})
 	}
+	return llm
 }
 
-func fetchLocalNews(city, country string) tea.Cmd {
-	return func() tea.Msg {
-		items, err := feeds.FetchLocalNews(context.Background(), city, country)
-		return localNewsMsg{items, err}
+// azure maps the azure config block to the provider's deployment
+func azure(cfg *config.Config) intel.Azure {
+	return intel.Azure{Resource: cfg.Azure.Endpoint, Deployment: cfg.Azure.Deployment, APIVersion: cfg.Azure.APIVersion}
+}
+
+// gateway combines llm_headers with provider's entry in llm_overrides
+func gateway(cfg *config.Config, provider string) intel.Gateway {
+	o := cfg.LLMOverrides[provider]
+	return intel.Gateway{
+		Endpoint:   o.Endpoint,
+		AuthHeader: o.AuthHeader,
+		AuthPrefix: o.AuthPrefix,
+		Headers:    cfg.LLMHeaders,
 	}
 }
 
-func fetchCrypto(pairs []string) tea.Cmd {
-	return func() tea.Msg {
-		prices, err := markets.FetchCryptoPrices(context.Background(), pairs)
+// BriefOptions maps brief_sections and brief_headline_count to the prompt options
+func BriefOptions(cfg *config.Config) intel.BriefOptions {
+	return intel.BriefOptions{
+		SkipThreats:      !cfg.BriefSections.Threats,
+		SkipCountryRisks: !cfg.BriefSections.CountryRisks,
+		Headlines:        cfg.BriefHeadlines,
+		Language:         cfg.BriefLanguage,
+	}
+}
+
+// briefOptions is BriefOptions plus what only the running dashboard knows,
+// such as the current local weather.
+func (m Model) briefOptions() intel.BriefOptions {
+	opts := BriefOptions(m.cfg)
+	if m.cfg.BriefWeather {
+		opts.Weather = m.weatherCond
+	}
+	return opts
+}
+
+// FeedOptions builds the feed filtering/ordering options from the config
+func FeedOptions(cfg *config.Config) feeds.Options {
+	return feeds.Options{
+		PinnedTopics:  cfg.PinnedTopics,
+		MutedSources:  cfg.MutedSources,
+		MutedKeywords: cfg.MutedKeywords,
+		ScanSummaries: cfg.ClassifySummaries,
+	}
+}
+
+// LocalSources maps the local news settings onto feeds.LocalSources
+func LocalSources(cfg *config.Config) feeds.LocalSources {
+	src := feeds.LocalSources{
+		City:     cfg.Location.City,
+		Country:  cfg.Location.Country,
+		Replace:  cfg.LocalFeedsMode == "replace",
+		Language: cfg.GoogleNews.Language,
+		Region:   cfg.GoogleNews.Region,
+	}
+	for _, f := range cfg.LocalFeeds {
+		if f.URL != "" {
+			src.Feeds = append(src.Feeds, struct{ Name, URL string }(f))
+		}
+	}
+	return src
+}
+
+func fetchGlobalNews(opts feeds.Options) fetchFunc {
+	return func(ctx context.Context) tea.Msg {
+		items, failed, err := feeds.FetchGlobalNews(ctx, opts)
+		return globalNewsMsg{items, failed, err}
+	}
+}
+
+func fetchLocalNews(src feeds.LocalSources, opts feeds.Options) fetchFunc {
+	return func(ctx context.Context) tea.Msg {
+		items, failed, err := feeds.FetchLocalNews(ctx, src, opts)
+		return localNewsMsg{items, failed, err}
+	}
+}
+
+func fetchCrypto(opts markets.CryptoOptions, pairs []string) fetchFunc {
+	return func(ctx context.Context) tea.Msg {
+		prices, err := markets.FetchCryptoPrices(ctx, opts, pairs)
 		return cryptoMsg{prices, err}
 	}
 }
 
-func fetchStocks() tea.Cmd {
-	return func() tea.Msg {
-		indices, err := markets.FetchStockIndices(context.Background())
+func fetchStocks() fetchFunc {
+	return func(ctx context.Context) tea.Msg {
+		indices, err := markets.FetchStockIndices(ctx)
 		return stockMsg{indices, err}
 	}
 }
 
-func fetchCommodities() tea.Cmd {
-	return func() tea.Msg {
-		commodities, err := markets.FetchCommodities(context.Background())
+func fetchCommodities(opts markets.CommodityOptions) fetchFunc {
+	return func(ctx context.Context) tea.Msg {
+		commodities, err := markets.FetchCommodities(ctx, opts)
 		return commodityMsg{commodities, err}
 	}
 }
 
-func fetchPolymarket() tea.Cmd {
-	return func() tea.Msg {
-		mkts, err := markets.FetchPredictionMarkets(context.Background())
+// CryptoOptions maps the crypto source settings and CoinGecko credentials
+func CryptoOptions(cfg *config.Config) markets.CryptoOptions {
+	return markets.CryptoOptions{
+		Primary:      cfg.CryptoProvider,
+		CoinGeckoKey: cfg.CoinGeckoKey,
+		CoinGeckoPro: cfg.CoinGeckoPro,
+	}
+}
+
+// CommodityOptions maps the config's currency/unit preferences for commodity prices
+func CommodityOptions(cfg *config.Config) markets.CommodityOptions {
+	return markets.CommodityOptions{Currency: cfg.BaseCurrency, Units: cfg.CommodityUnits}
+}
+
+func fetchAlerts(url string, headers map[string]string) fetchFunc {
+	return func(ctx context.Context) tea.Msg {
+		items, err := feeds.FetchAlerts(ctx, url, headers)
+		return alertsMsg{items, err}
+	}
+}
+
+func fetchPolymarket() fetchFunc {
+	return func(ctx context.Context) tea.Msg {
+		mkts, err := markets.FetchPredictionMarkets(ctx)
 		return polymarketMsg{mkts, err}
 	}
 }
 
-func fetchWeather(lat, lon float64, city string) tea.Cmd {
+// fetchWeather fetches conditions for loc, geocoding the city first if the
+// config has no coordinates. Resolved coordinates are persisted best-effort.
+func fetchWeather(loc config.Location, provider string) fetchFunc {
+	return func(ctx context.Context) tea.Msg {
+		var resolved *config.Location
+		// Fixture weather needs no coordinates, and must not geocode or save
+		if !loc.HasCoordinates() && fixtures.Dir() == "" {
+			r, err := config.ResolveLocation(ctx, loc)
+			if err != nil {
+				return weatherMsg{err: err}
+			}
+			_ = config.SaveLocation(r)
+			resolved = &r
+			loc = r
+		}
+		cond, forecast, err := weather.Fetch(ctx, loc.Latitude, loc.Longitude, loc.City, provider)
+		return weatherMsg{cond: cond, forecast: forecast, resolved: resolved, err: err}
+	}
+}
+
+// autoBrief starts a brief nobody asked for — on news load or on viewing the
+// overview, per brief_trigger — unless one is shown or already on its way.
+// The cache is tried first, so this only spends tokens when it is expired.
+func (m *Model) autoBrief() tea.Cmd {
+	if m.cfg.LLMAPIKey == "" || m.brief != nil || m.loading["brief"] || len(m.globalNews) == 0 {
+		return nil
+	}
+	m.loading["brief"] = true
+	return fetchBrief(LLMConfig(m.cfg), m.globalNews, m.briefOptions(), m.cfg.BriefCacheMins, false)
+}
+
+// softRefreshBrief regenerates a brief older than brief_soft_refresh_minutes
+// in the background. The old brief stays on screen until the new one
+// arrives, so a restart shows something instantly and still catches up.
+func (m *Model) softRefreshBrief() tea.Cmd {
+	soft := time.Duration(m.cfg.BriefSoftMins) * time.Minute
+	if m.cfg.LLMAPIKey == "" || m.cfg.BriefTrigger == "manual" || soft <= 0 || m.brief == nil || time.Since(m.brief.GeneratedAt) < soft ||
+		m.loading["brief"] || m.loading["briefRefresh"] || len(m.globalNews) == 0 {
+		return nil
+	}
+	m.loading["briefRefresh"] = true
+	fetch := fetchBrief(LLMConfig(m.cfg), m.globalNews, m.briefOptions(), m.cfg.BriefCacheMins, true)
 	return func() tea.Msg {
-		cond, forecast, err := weather.Fetch(context.Background(), lat, lon, city)
-		return weatherMsg{cond, forecast, err}
+		msg := fetch().(briefMsg)
+		msg.background = true
+		return msg
 	}
 }
 
 // fetchBrief generates a brief, using the disk cache unless forceRefresh is true.
 // cacheMins=0 means always generate fresh (cache disabled).
-func fetchBrief(cfg intel.LLMConfig, items []feeds.NewsItem, cacheMins int, forceRefresh bool) tea.Cmd {
+func fetchBrief(cfg intel.LLMConfig, items []feeds.NewsItem, opts intel.BriefOptions, cacheMins int, forceRefresh bool) tea.Cmd {
 	return func() tea.Msg {
 		// Try cache first (unless forced refresh or cache disabled)
 		if !forceRefresh && cacheMins > 0 {
@@ -1468,9 +2406,25 @@ func fetchBrief(cfg intel.LLMConfig, items []feeds.NewsItem, cacheMins int, forc
 				return briefMsg{brief: cached, fromCache: true}
 			}
 		}
-		// Cache miss or disabled — call LLM
-		b, err := intel.GenerateBrief(context.Background(), cfg, items)
-		return briefMsg{brief: b, err: err, fromCache: false}
+		// Cache miss or disabled — call LLM, but only with something to brief on
+		if len(items) == 0 {
+			return briefMsg{err: errors.New("news not loaded yet")}
+		}
+		b, err := intel.GenerateBrief(context.Background(), cfg, items, opts)
+		if err != nil {
+			// Offer the last cached brief, however old, to show under the error
+			last, _ := intel.LoadCachedBrief(0)
+			return briefMsg{err: err, last: last}
+		}
+		return briefMsg{brief: b, fromCache: false}
+	}
+}
+
+// fetchCountryRisks re-requests only the country risk index
+func fetchCountryRisks(cfg intel.LLMConfig, items []feeds.NewsItem, opts intel.BriefOptions) tea.Cmd {
+	return func() tea.Msg {
+		risks, err := intel.GenerateCountryRisks(context.Background(), cfg, items, opts)
+		return countryRisksMsg{risks, err}
 	}
 }
 
@@ -1490,42 +2444,102 @@ func loadCachedBrief(cfg *config.Config) tea.Cmd {
 	}
 }
 
-// fetchLocalBrief generates a local brief, using the disk cache unless forceRefresh is true.
-func fetchLocalBrief(cfg intel.LLMConfig, city string, items []feeds.NewsItem, cond *weather.Conditions, forecast []weather.DayForecast, cacheMins int, forceRefresh bool) tea.Cmd {
+// fetchLocalBrief generates a local brief (the safety variant if safety is
+// set), using the disk cache unless forceRefresh is true.
+func fetchLocalBrief(cfg intel.LLMConfig, city string, items []feeds.NewsItem, cond *weather.Conditions, forecast []weather.DayForecast, cacheMins int, safety, forceRefresh bool) tea.Cmd {
+	load, generate := intel.LoadCachedLocalBrief, intel.GenerateLocalBrief
+	if safety {
+		load, generate = intel.LoadCachedSafetyBrief, intel.GenerateSafetyBrief
+	}
 	return func() tea.Msg {
 		if !forceRefresh && cacheMins > 0 {
 			maxAge := time.Duration(cacheMins) * time.Minute
-			cached, err := intel.LoadCachedLocalBrief(maxAge)
+			cached, err := load(maxAge)
 			if err == nil && cached != nil {
-				return localBriefMsg{brief: cached, fromCache: true}
+				return localBriefMsg{brief: cached, fromCache: true, safety: safety}
 			}
 		}
-		b, err := intel.GenerateLocalBrief(context.Background(), cfg, city, items, cond, forecast)
-		return localBriefMsg{brief: b, err: err, fromCache: false}
+		b, err := generate(context.Background(), cfg, city, items, cond, forecast)
+		return localBriefMsg{brief: b, err: err, fromCache: false, safety: safety}
 	}
 }
 
-// loadCachedLocalBrief is fired on Init to immediately populate the local brief from
-// disk if a valid cache exists.
-func loadCachedLocalBrief(cfg *config.Config) tea.Cmd {
+// loadCachedLocalBrief is fired on Init to immediately populate the local brief
+// (or its safety variant) from disk if a valid cache exists.
+func loadCachedLocalBrief(cfg *config.Config, safety bool) tea.Cmd {
+	load := intel.LoadCachedLocalBrief
+	if safety {
+		load = intel.LoadCachedSafetyBrief
+	}
 	return func() tea.Msg {
 		if cfg.BriefCacheMins == 0 {
 			return nil
 		}
 		maxAge := time.Duration(cfg.BriefCacheMins) * time.Minute
-		cached, err := intel.LoadCachedLocalBrief(maxAge)
+		cached, err := load(maxAge)
 		if err != nil || cached == nil {
 			return nil
 		}
-		return localBriefMsg{brief: cached, fromCache: true}
+		return localBriefMsg{brief: cached, fromCache: true, safety: safety}
 	}
 }
 
 // ─── Tea commands (continued) ────────────────────────────────────────────────
 
-// openURL opens a URL in the system default browser (cross-platform)
-func openURL(url string) tea.Cmd {
+// snapshot collects the data currently on screen for the Markdown report
+func (m Model) snapshot() report.Snapshot {
+	return report.Snapshot{
+		TakenAt:     time.Now(),
+		City:        m.cfg.Location.City,
+		TempUnit:    m.cfg.TempUnit,
+		WindUnit:    m.cfg.WindUnit,
+		Brief:       m.brief.WithSections(BriefOptions(m.cfg)),
+		Crypto:      m.cryptoPrices,
+		Indices:     m.stockIndices,
+		Commodities: m.commodities,
+		Weather:     m.weatherCond,
+		Forecast:    m.forecast,
+	}
+}
+
+// copySnapshot puts the snapshot on the clipboard, falling back to a file
+// in the home directory when no clipboard tool is available.
+func copySnapshot(md string) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(md); err == nil {
+			return snapshotMsg{copied: true}
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return snapshotMsg{err: err}
+		}
+		path, err := report.WriteFile(home, md, time.Now())
+		return snapshotMsg{path: path, err: err}
+	}
+}
+
+// openURL opens a URL with browserCmd if set, otherwise in the system
+// default browser (cross-platform). In browserCmd, %u is replaced by the
+// URL; if it has no %u the URL is appended as the last argument.
+func openURL(browserCmd, url string) tea.Cmd {
 	return func() tea.Msg {
+		if fields := strings.Fields(browserCmd); len(fields) > 0 {
+			args := make([]string, 0, len(fields))
+			substituted := false
+			for _, f := range fields[1:] {
+				if strings.Contains(f, "%u") {
+					f = strings.ReplaceAll(f, "%u", url)
+					substituted = true
+				}
+				args = append(args, f)
+			}
+			if !substituted {
+				args = append(args, url)
+			}
+			execCommand(fields[0], args...)
+			return openURLMsg{url: url}
+		}
+
 		var cmd string
 		var args []string
 		// Detect OS: try xdg-open (Linux), open (macOS), start (Windows)
@@ -1547,10 +2561,21 @@ func openURL(url string) tea.Cmd {
 
 // ─── Helpers ──────────────────────────────────────────────────────────────────
 
+// maxClockSkew is how far in the future a feed timestamp may be before it is
+// treated as bogus rather than a publisher's clock running ahead
+const maxClockSkew = 24 * time.Hour
+
+// bogusTime reports whether t is missing or implausibly far in the future
+func bogusTime(t time.Time) bool {
+	return t.IsZero() || time.Until(t) > maxClockSkew
+}
+
 func formatAge(t time.Time) string {
 	d := time.Since(t)
 	switch {
-	case d < time.Minute:
+	case bogusTime(t):
+		return "unknown"
+	case d < time.Minute: // includes small future skew
 		return "just now"
 	case d < time.Hour:
 		return fmt.Sprintf("%dm ago", int(d.Minutes()))
@@ -1561,6 +2586,126 @@ func formatAge(t time.Time) string {
 	}
 }
 
+// ageStyle picks a style on a bright-to-muted gradient based on how old t is
+func ageStyle(t time.Time) lipgloss.Style {
+	d := time.Since(t)
+	switch {
+	case bogusTime(t):
+		return StyleAgeStale
+	case d < 15*time.Minute:
+		return StyleAgeFresh
+	case d < time.Hour:
+		return StyleAgeRecent
+	case d < 6*time.Hour:
+		return StyleAge
+	default:
+		return StyleAgeStale
+	}
+}
+
+// newsItems is the Global News list: webhook alerts first, then feed news
+func (m Model) newsItems() []feeds.NewsItem {
+	return feeds.MergeAlerts(m.alerts, m.globalNews)
+}
+
+// filteredNews is the news ranked by relevance and narrowed to
+// countryFilter, if set
+func (m Model) filteredNews() []feeds.NewsItem {
+	news := m.rankRelevant(m.newsItems())
+	if m.countryFilter == "" {
+		return news
+	}
+	mentions := intel.CountryMatcher(m.countryFilter)
+	var kept []feeds.NewsItem
+	for _, item := range news {
+		if mentions(item.Title) || mentions(item.Description) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// newsListItems is the News tab's selectable list: every article, or in
+// compare mode the clustered stories' articles, group after group
+func (m Model) newsListItems() []feeds.NewsItem {
+	if !m.compare {
+		return m.filteredNews()
+	}
+	var items []feeds.NewsItem
+	for _, cluster := range m.newsClusters {
+		items = append(items, cluster...)
+	}
+	return items
+}
+
+// updateClusters regroups the news for compare mode; a no-op when it's off
+func (m *Model) updateClusters() {
+	if m.compare {
+		m.newsClusters = feeds.Clusters(m.filteredNews())
+	} else {
+		m.newsClusters = nil
+	}
+}
+
+// localItems is the Local tab's list; with cross_dedup: hide it leaves out
+// stories already in Global News.
+func (m Model) localItems() []feeds.NewsItem {
+	if m.cfg.CrossDedup != "hide" || len(m.globalNews) == 0 {
+		return m.localNews
+	}
+	global := m.globalStoryKeys()
+	var kept []feeds.NewsItem
+	for _, item := range m.localNews {
+		if !global[feeds.StoryKey(item.Title)] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// globalStoryKeys indexes the global news by feeds.StoryKey
+func (m Model) globalStoryKeys() map[string]bool {
+	keys := make(map[string]bool, len(m.globalNews))
+	for _, item := range m.globalNews {
+		keys[feeds.StoryKey(item.Title)] = true
+	}
+	return keys
+}
+
+// sourceStyle tags webhook alerts distinctly from RSS sources
+func sourceStyle(item feeds.NewsItem) lipgloss.Style {
+	if item.IsAlert {
+		return StyleAlertSource
+	}
+	return StyleSource
+}
+
+// sourceLabel renders an item's source: the full name, or with
+// source_tags a short colored tag such as " REU ", followed by a muted
+// "(5 sources)" when several feeds ran the story
+func (m Model) sourceLabel(item feeds.NewsItem) string {
+	count := ""
+	if m.cfg.SourceCounts && item.SourceCount > 1 {
+		count = StyleMuted.Render(fmt.Sprintf(" (%d sources)", item.SourceCount))
+	}
+	if !m.cfg.SourceTags || item.IsAlert {
+		return sourceStyle(item).Render(item.Source) + count
+	}
+	return lipgloss.NewStyle().
+		Foreground(colorBg).
+		Background(sourceColor(item.Source)).
+		Bold(true).
+		Render(fmt.Sprintf(" %-3s ", sourceAbbrev(item.Source))) + count
+}
+
+// pinMark returns the marker shown before pinned-topic items
+func (m Model) pinMark(item feeds.NewsItem) string {
+	if !item.Pinned {
+		return ""
+	}
+	return StylePinned.Render(m.icon("📌") + " ")
+}
+
 func probabilityBar(p float64, width int) string {
 	filled := int(p * float64(width))
 	empty := width - filled
@@ -1575,29 +2720,74 @@ func probabilityBar(p float64, width int) string {
 	}
 }
 
+// wordWrap wraps s at width runes. Words longer than a whole line (URLs,
+// hashtags) are hard-broken so nothing runs past the panel border.
 func wordWrap(s string, width int) string {
 	if width <= 0 {
 		return s
 	}
-	words := strings.Fields(s)
 	var lines []string
-	var line strings.Builder
-	for _, w := range words {
-		if line.Len()+len(w)+1 > width {
-			lines = append(lines, line.String())
-			line.Reset()
+	var line []rune
+	for _, w := range strings.Fields(s) {
+		word := []rune(w)
+		if len(line) > 0 && len(line)+1+len(word) > width {
+			lines = append(lines, string(line))
+			line = line[:0]
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
 		}
-		if line.Len() > 0 {
-			line.WriteByte(' ')
+		line = append(line, word...)
+		for len(line) > width {
+			lines = append(lines, string(line[:width]))
+			line = append(line[:0], line[width:]...)
 		}
-		line.WriteString(w)
 	}
-	if line.Len() > 0 {
-		lines = append(lines, line.String())
+	if len(line) > 0 {
+		lines = append(lines, string(line))
 	}
 	return strings.Join(lines, "\n")
 }
 
+// isBreaking reports whether item is a critical story fresh enough to flash.
+// It is evaluated at render time, so the flash decays on its own.
+func (m Model) isBreaking(item feeds.NewsItem) bool {
+	if item.ThreatLevel != feeds.ThreatCritical || m.cfg.BreakingMins < 0 || bogusTime(item.Published) {
+		return false
+	}
+	return time.Since(item.Published) < time.Duration(m.cfg.BreakingMins)*time.Minute
+}
+
+// threatBadge renders the level badge padded to width, or the BREAKING
+// flash for very recent critical items.
+func (m Model) threatBadge(item feeds.NewsItem, width int) string {
+	if m.isBreaking(item) {
+		return StyleBreaking.Render(" " + padRight(m.icon("🔴")+" BREAKING", width))
+	}
+	return threatStyle(item.ThreatLevel).Render(" " + padRight(m.tr(item.ThreatLevel.String()), width))
+}
+
+// newsStats summarizes a news list as "120 items · 3 critical · 12 high ·
+// 40 medium", leaving out levels with no items; "" for an empty list
+func (m Model) newsStats(items []feeds.NewsItem) string {
+	if len(items) == 0 {
+		return ""
+	}
+	counts := map[feeds.ThreatLevel]int{}
+	for _, item := range items {
+		counts[item.ThreatLevel]++
+	}
+	parts := []string{StyleMuted.Render(fmt.Sprintf("%d items", len(items)))}
+	for _, level := range []feeds.ThreatLevel{feeds.ThreatCritical, feeds.ThreatHigh, feeds.ThreatMedium} {
+		if n := counts[level]; n > 0 {
+			// The badge background as text color, so the line stays light
+			style := lipgloss.NewStyle().Foreground(threatStyle(level).GetBackground())
+			parts = append(parts, style.Render(fmt.Sprintf("%d %s", n, strings.ToLower(m.tr(level.String())))))
+		}
+	}
+	return strings.Join(parts, StyleMuted.Render(" · "))
+}
+
 func threatStyle(level feeds.ThreatLevel) lipgloss.Style {
 	switch level {
 	case feeds.ThreatCritical:
@@ -1627,11 +2817,41 @@ func maxInt(a, b int) int {
 	return b
 }
 
+// truncate shortens s to n runes, ending in "…" when cut
 func truncate(s string, n int) string {
-	if len(s) <= n {
+	runes := []rune(s)
+	if len(runes) <= n {
 		return s
 	}
-	return s[:n-1] + "…"
+	return string(runes[:n-1]) + "…"
+}
+
+// astroLine returns today's sunrise, sunset and moon phase, or "" when
+// weather_astro is off.
+func (m Model) astroLine() string {
+	if !m.cfg.WeatherAstro {
+		return ""
+	}
+	var parts []string
+	if len(m.forecast) > 0 && m.forecast[0].Sunrise != "" {
+		today := m.forecast[0]
+		parts = append(parts, m.icon("🌅")+" "+today.Sunrise, m.icon("🌇")+" "+today.Sunset)
+	}
+	icon, name := weather.MoonPhase(time.Now())
+	parts = append(parts, m.glyph(icon)+name)
+	return strings.Join(parts, "   ")
+}
+
+// weatherAdvice returns the clothing hint for current conditions, or "" if disabled
+func (m Model) weatherAdvice() string {
+	if !m.cfg.WeatherAdvice {
+		return ""
+	}
+	var today *weather.DayForecast
+	if len(m.forecast) > 0 {
+		today = &m.forecast[0]
+	}
+	return weather.Advice(m.weatherCond, today)
 }
 
 func (m Model) formatTemp(celsius float64) string {
@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"watchtower/config"
+	"watchtower/feeds"
+	"watchtower/intel"
+	"watchtower/markets"
+	"watchtower/weather"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWordWrap(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	tests := []struct {
+		name  string
+		in    string
+		width int
+		want  string
+	}{
+		{"tokenless", long, 40, long[:40] + "\n" + long[40:80] + "\n" + long[80:]},
+		{"words", "the quick brown fox jumps", 10, "the quick\nbrown fox\njumps"},
+		{"long word after short", "see " + long[:15], 10, "see\nxxxxxxxxxx\nxxxxx"},
+		{"zero width", "unchanged text", 0, "unchanged text"},
+		{"empty", "", 10, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wordWrap(tt.in, tt.width)
+			if got != tt.want {
+				t.Errorf("wordWrap(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+			}
+			if tt.width <= 0 {
+				return
+			}
+			for _, line := range strings.Split(got, "\n") {
+				if n := utf8.RuneCountInString(line); n > tt.width {
+					t.Errorf("line %q is %d runes, wider than %d", line, n, tt.width)
+				}
+			}
+		})
+	}
+}
+
+// testConfig loads the sample config from a scratch home, so tests get the
+// real defaults and nothing they save touches the user's files
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	if _, err := config.WriteSample(false); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+// dataMsgs is one of each data message, as the fetchers would send them
+func dataMsgs() []tea.Msg {
+	now := time.Now()
+	news := []feeds.NewsItem{
+		{Title: "Missile strike on Kyiv", Source: "Reuters", Published: now.Add(-time.Minute), URL: "https://example.com/a", ThreatLevel: feeds.ThreatCritical, Description: "Air defence was active overnight."},
+		{Title: "Central bank holds rates", Source: "BBC", Published: now.Add(-3 * time.Hour), ThreatLevel: feeds.ThreatLow},
+		{Title: "Undated story", Source: "AP"},
+	}
+	return []tea.Msg{
+		globalNewsMsg{items: news},
+		localNewsMsg{items: news[1:]},
+		alertsMsg{items: []feeds.NewsItem{{Title: "Webhook alert", IsAlert: true, Published: now}}},
+		cryptoMsg{prices: []markets.CryptoPrice{{ID: "bitcoin", Symbol: "BTC", Name: "Bitcoin", PriceUSD: 65000, Change24h: -2.5}}},
+		stockMsg{indices: []markets.StockIndex{{Symbol: "^GSPC", Name: "S&P 500", Price: 5000, ChangePct: 0.4}}},
+		commodityMsg{commodities: []markets.Commodity{{Symbol: "GC=F", Name: "Gold", Price: 2300, Unit: "oz", Currency: "USD", ChangePct: 1.1}}},
+		polymarketMsg{markets: []markets.PredictionMarket{{Title: "Will it rain?", Probability: 0.4, HasProbability: true}}},
+		weatherMsg{
+			cond:     &weather.Conditions{City: "Lisbon", TempC: 21, Description: "Clear", Icon: "☀", IsDay: true, UpdatedAt: now},
+			forecast: []weather.DayForecast{{Date: now, MaxTempC: 24, MinTempC: 15, Icon: "☀", Desc: "Clear", Sunrise: "07:10", Sunset: "19:40"}},
+		},
+		briefMsg{brief: &intel.Brief{
+			Summary:      "Tensions rose.",
+			KeyThreats:   []string{"Escalation in Ukraine"},
+			CountryRisks: []intel.CountryRisk{{Country: "Ukraine", Score: 90, Reason: "war"}},
+			GeneratedAt:  now,
+		}},
+		countryRisksMsg{risks: []intel.CountryRisk{{Country: "Sudan", Score: 80, Reason: "civil war"}}},
+		localBriefMsg{brief: &intel.LocalBrief{Summary: "Quiet day.", GeneratedAt: now}},
+		localBriefMsg{brief: &intel.LocalBrief{Summary: "Stay hydrated.", Actions: []string{"Carry water"}, GeneratedAt: now}, safety: true},
+	}
+}
+
+// TestViewBeforeWindowSize feeds data before the first WindowSizeMsg, as a
+// fast fetch or a cached brief can, then renders every tab at every width
+func TestViewBeforeWindowSize(t *testing.T) {
+	cfg := testConfig(t)
+	step := 1
+	if testing.Short() {
+		step = 7
+	}
+	tests := []struct {
+		name string
+		msgs []tea.Msg
+	}{
+		{"none", nil},
+		{"all", dataMsgs()},
+	}
+	for _, msg := range dataMsgs() {
+		tests = append(tests, struct {
+			name string
+			msgs []tea.Msg
+		}{fmt.Sprintf("%T", msg), []tea.Msg{msg}})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m tea.Model = NewModel(cfg)
+			for _, msg := range tt.msgs {
+				m, _ = m.Update(msg)
+			}
+			if v := m.View(); strings.Contains(v, "render error") {
+				t.Fatalf("before any WindowSizeMsg: %s", v)
+			}
+			for w := 1; w <= 200; w += step {
+				m, _ = m.Update(tea.WindowSizeMsg{Width: w, Height: 40})
+				for tab := range newTabs() {
+					mm := m.(Model)
+					mm.activeTab = tab
+					if v := mm.View(); strings.Contains(v, "render error") {
+						t.Fatalf("tab %d width %d: %s", tab, w, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"zero", time.Time{}, "unknown"},
+		{"slightly future", now.Add(30 * time.Second), "just now"},
+		{"far future", now.Add(48 * time.Hour), "unknown"},
+		{"minutes", now.Add(-5*time.Minute - time.Second), "5m ago"},
+		{"hours", now.Add(-3*time.Hour - time.Second), "3h ago"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatAge(tt.t); got != tt.want {
+				t.Errorf("formatAge(%v) = %q, want %q", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBogusTime(t *testing.T) {
+	now := time.Now()
+	if !bogusTime(time.Time{}) {
+		t.Error("zero time should be bogus")
+	}
+	if !bogusTime(now.Add(48 * time.Hour)) {
+		t.Error("two days ahead should be bogus")
+	}
+	if bogusTime(now.Add(time.Hour)) {
+		t.Error("an hour of clock skew should be tolerated")
+	}
+	if bogusTime(now.Add(-3 * time.Hour)) {
+		t.Error("a past time should not be bogus")
+	}
+}
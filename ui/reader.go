@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"watchtower/feeds"
+	"watchtower/markets"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Article actions selectable via enter_action
+const (
+	actionBrowser = "browser"
+	actionReader  = "reader"
+	actionCopy    = "copy"
+)
+
+// readerState holds the in-terminal article reader overlay
+type readerState struct {
+	open    bool
+	loading bool
+	item    feeds.NewsItem
+	text    string
+	err     string
+	vp      viewport.Model
+}
+
+// articleMsg carries the extracted text of an article for the reader
+type articleMsg struct {
+	url  string
+	text string
+	err  error
+}
+
+// clipboardMsg reports the result of copying something to the clipboard
+type clipboardMsg struct {
+	what string
+	err  error
+}
+
+// selectedArticle returns the highlighted article on the active tab, if any
+func (m Model) selectedArticle() (feeds.NewsItem, bool) {
+	if l := m.activeList(); l != nil && l.items != nil {
+		items := l.items(m)
+		if idx := *l.selected(&m); idx < len(items) {
+			return items[idx], true
+		}
+	}
+	return feeds.NewsItem{}, false
+}
+
+// selectedMarket returns the highlighted prediction market on the Markets tab
+func (m Model) selectedMarket() (markets.PredictionMarket, bool) {
+	if i := m.selectedPolyIdx(); m.activeTab == TabMarkets && i >= 0 && i < len(m.polyMarkets) {
+		return m.polyMarkets[i], true
+	}
+	return markets.PredictionMarket{}, false
+}
+
+// selectedPolyIdx is the Markets tab selection within the prediction
+// markets, negative while an index or commodity row is selected
+func (m Model) selectedPolyIdx() int {
+	return m.selectedMarketIdx - len(m.quoteRows())
+}
+
+// selectedQuote returns the highlighted index or commodity on the Markets tab
+func (m Model) selectedQuote() (marketQuote, bool) {
+	rows := m.quoteRows()
+	if m.activeTab == TabMarkets && m.selectedMarketIdx < len(rows) {
+		return rows[m.selectedMarketIdx], true
+	}
+	return marketQuote{}, false
+}
+
+// openQuote opens an index or commodity's Yahoo Finance page in the browser
+func (m *Model) openQuote(q marketQuote) tea.Cmd {
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+	url := markets.YahooURL(q.symbol)
+	if url == "" {
+		m.statusMsg = "No Yahoo Finance symbol for " + q.name
+		return nil
+	}
+	m.statusMsg = "Opening: " + q.name
+	return openURL(m.cfg.BrowserCommand, url)
+}
+
+// openMarket opens a prediction market's Polymarket page in the browser
+func (m *Model) openMarket(pm markets.PredictionMarket) tea.Cmd {
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+	url := pm.URL()
+	if url == "" {
+		m.statusMsg = "No Polymarket link for this market"
+		return nil
+	}
+	m.statusMsg = "Opening: " + truncate(pm.Title, 60)
+	return openURL(m.cfg.BrowserCommand, url)
+}
+
+// articleAction opens, reads or copies item depending on action
+func (m *Model) articleAction(action string, item feeds.NewsItem) tea.Cmd {
+	if item.URL == "" {
+		m.statusMsg = "No URL available for this article"
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+		return nil
+	}
+	switch action {
+	case actionReader:
+		m.reader = readerState{open: true, loading: true, item: item, vp: m.reader.vp}
+		m.reader.vp.SetContent(m.renderReaderContent())
+		m.reader.vp.GotoTop()
+		return fetchArticle(item.URL)
+	case actionCopy:
+		return copyText(item.URL, "URL")
+	default:
+		m.statusMsg = "Opening: " + truncate(item.Title, 60)
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+		return openURL(m.cfg.BrowserCommand, item.URL)
+	}
+}
+
+// updateReader handles keys while the reader overlay is open
+func (m Model) updateReader(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "backspace", "v":
+		m.reader.open = false
+	case "j", "down":
+		m.reader.vp.LineDown(1)
+	case "k", "up":
+		m.reader.vp.LineUp(1)
+	case "d", "pgdown", " ":
+		m.reader.vp.HalfViewDown()
+	case "u", "pgup":
+		m.reader.vp.HalfViewUp()
+	case "g":
+		m.reader.vp.GotoTop()
+	case "G":
+		m.reader.vp.GotoBottom()
+	case "o":
+		return m, m.articleAction(actionBrowser, m.reader.item)
+	case "c":
+		return m, m.articleAction(actionCopy, m.reader.item)
+	}
+	return m, nil
+}
+
+// renderReaderContent renders the article header and word-wrapped body
+func (m Model) renderReaderContent() string {
+	r := m.reader
+	w := m.width - 6
+	if w < 20 {
+		w = 20
+	}
+
+	var sb strings.Builder
+	sb.WriteString(StyleSelectedTitle.Render(wordWrap(r.item.Title, w)) + "\n")
+	sb.WriteString(StyleSource.Render(r.item.Source) + "  " +
+		ageStyle(r.item.Published).Render(formatAge(r.item.Published)) + "\n")
+	sb.WriteString(StyleMuted.Render(truncate(r.item.URL, w)) + "\n")
+	sb.WriteString(StyleDivider.Render(strings.Repeat("─", w)) + "\n\n")
+
+	switch {
+	case r.loading:
+		sb.WriteString("  " + m.spinner.View() + " Fetching article...")
+	case r.err != "":
+		sb.WriteString(StyleError.Render("⚠ "+r.err) + "\n\n")
+		sb.WriteString(StyleMuted.Render("Press o to open in the browser instead."))
+	default:
+		for _, para := range strings.Split(r.text, "\n\n") {
+			sb.WriteString(wordWrap(para, w) + "\n\n")
+		}
+	}
+	return sb.String()
+}
+
+func fetchArticle(url string) tea.Cmd {
+	return func() tea.Msg {
+		text, err := feeds.FetchArticleText(context.Background(), url)
+		return articleMsg{url: url, text: text, err: err}
+	}
+}
+
+// copyText puts text on the system clipboard
+func copyText(text, what string) tea.Cmd {
+	return func() tea.Msg {
+		return clipboardMsg{what: what, err: clipboard.WriteAll(text)}
+	}
+}
+
+// enterLabel describes the configured enter action for footer hints
+func (m Model) enterLabel() string {
+	switch m.cfg.EnterAction {
+	case actionReader:
+		return "enter read"
+	case actionCopy:
+		return "enter copy URL"
+	default:
+		return "enter open in browser"
+	}
+}
+
+// articleKeysHint lists the alternate article actions not bound to enter
+func (m Model) articleKeysHint() string {
+	var keys []string
+	if m.cfg.EnterAction != actionBrowser {
+		keys = append(keys, "o browser")
+	}
+	if m.cfg.EnterAction != actionReader {
+		keys = append(keys, "v read")
+	}
+	if m.cfg.EnterAction != actionCopy {
+		keys = append(keys, "c copy URL")
+	}
+	keys = append(keys, "e summary")
+	return fmt.Sprintf("%s  %s", m.enterLabel(), strings.Join(keys, "  "))
+}
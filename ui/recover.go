@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"watchtower/paths"
+)
+
+// Rendering runs on whatever data the feeds and APIs sent. A panic there
+// (an empty slice, a width going negative) would kill the program and leave
+// the terminal in the alternate screen, so View and the tab renderers
+// recover and draw the error in place of the panel instead.
+
+// renderPanicLog is the file recovered render panics are appended to,
+// with a stack trace, for bug reports
+const renderPanicLog = "render-panics.log"
+
+// recoverRender turns a panic in the renderer named where into a visible
+// message in *out and logs it. Use as: defer recoverRender("news", &out).
+func recoverRender(where string, out *string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	msg := fmt.Sprintf("render error (recovered) in %s: %v", where, r)
+	if path := logRenderPanic(msg); path != "" {
+		msg += "\nDetails in " + path
+	}
+	*out = StyleError.Render("⚠ " + msg)
+}
+
+// logRenderPanic appends msg and the current stack to the panic log and
+// returns its path, or "" if it couldn't be written
+func logRenderPanic(msg string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	dir := paths.CacheDir(home)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return ""
+	}
+	path := filepath.Join(dir, renderPanicLog)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	fmt.Fprintf(f, "%s %s\n%s\n", time.Now().Format(time.RFC3339), msg, debug.Stack())
+	return path
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"strings"
+
+	"watchtower/feeds"
+	"watchtower/intel"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// relevance: mode boost or only ranks Global News by whether a headline
+// names the user's city, their country or one of relevance.keywords. The
+// brief and the glance view still see all of it.
+
+// relevanceMode is the relevance mode in effect; off without anything to
+// match against
+func (m Model) relevanceMode() string {
+	if m.relevanceMatcher() == nil {
+		return "off"
+	}
+	return m.cfg.Relevance.Mode
+}
+
+// relevanceMatcher tests a headline for the location and keywords, nil when
+// relevance is off or there is nothing configured to look for
+func (m Model) relevanceMatcher() func(title string) bool {
+	mode := m.cfg.Relevance.Mode
+	if mode != "boost" && mode != "only" {
+		return nil
+	}
+	var terms []string
+	for _, t := range append([]string{m.cfg.Location.City}, m.cfg.Relevance.Keywords...) {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			terms = append(terms, t)
+		}
+	}
+	country := countryName(m.cfg.Location.Country)
+	if len(terms) == 0 && country == "" {
+		return nil
+	}
+	mentions := intel.CountryMatcher(country)
+	return func(title string) bool {
+		lower := strings.ToLower(title)
+		for _, t := range terms {
+			if strings.Contains(lower, t) {
+				return true
+			}
+		}
+		return mentions(title)
+	}
+}
+
+// countryName is the English name of an ISO 3166-1 country code ("PT" →
+// "Portugal"); anything that isn't a code is returned as is
+func countryName(code string) string {
+	code = strings.TrimSpace(code)
+	region, err := language.ParseRegion(code)
+	if err != nil || len(code) != 2 {
+		return code
+	}
+	if name := display.English.Regions().Name(region); name != "" {
+		return intel.NormalizeCountry(name)
+	}
+	return code
+}
+
+// rankRelevant applies the relevance mode to the Global News list
+func (m Model) rankRelevant(items []feeds.NewsItem) []feeds.NewsItem {
+	match := m.relevanceMatcher()
+	if match == nil {
+		return items
+	}
+	return feeds.RankRelevant(items, match, m.cfg.Relevance.Mode == "only")
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"watchtower/intel"
+)
+
+// riskOrder is how the country risk panel orders its rows; O cycles it
+type riskOrder int
+
+const (
+	riskByScore riskOrder = iota // highest risk first
+	riskByName                   // alphabetical
+	riskByDelta                  // biggest rise since the previous brief first
+)
+
+func (o riskOrder) String() string {
+	switch o {
+	case riskByName:
+		return "name"
+	case riskByDelta:
+		return "change"
+	default:
+		return "score"
+	}
+}
+
+// sortedRisks is a copy of the brief's country risks in the chosen order.
+// Ties, and countries without a previous score when sorting by change,
+// keep the order the LLM returned them in.
+func (m Model) sortedRisks() []intel.CountryRisk {
+	if m.brief == nil {
+		return nil
+	}
+	risks := append([]intel.CountryRisk(nil), m.brief.CountryRisks...)
+	switch m.riskOrder {
+	case riskByName:
+		sort.SliceStable(risks, func(i, j int) bool {
+			return strings.ToLower(risks[i].Country) < strings.ToLower(risks[j].Country)
+		})
+	case riskByDelta:
+		sort.SliceStable(risks, func(i, j int) bool {
+			di, okI := m.riskDelta(risks[i])
+			dj, okJ := m.riskDelta(risks[j])
+			if okI != okJ {
+				return okI
+			}
+			return di > dj
+		})
+	default:
+		sort.SliceStable(risks, func(i, j int) bool { return risks[i].Score > risks[j].Score })
+	}
+	return risks
+}
+
+// riskDelta is the change in a country's score since the previous brief,
+// false if it wasn't scored then
+func (m Model) riskDelta(cr intel.CountryRisk) (int, bool) {
+	prev, ok := m.prevRisks[intel.NormalizeCountry(cr.Country)]
+	return cr.Score - prev, ok
+}
+
+// loadPrevRisks reads the scores of the brief before the current one from
+// the risk history, for sorting and marking by change
+func (m *Model) loadPrevRisks() {
+	m.prevRisks = nil
+	if m.brief == nil {
+		return
+	}
+	records, err := intel.LoadRiskHistory()
+	if err != nil {
+		m.statusMsg = "Could not read risk history: " + err.Error()
+		return
+	}
+	m.prevRisks = intel.PreviousRisks(records, m.brief.GeneratedAt)
+}
+
+// formatRiskDelta renders a score change as a fixed-width "▲ 5" / "▼ 3",
+// blank when there is nothing to compare against
+func formatRiskDelta(d int, ok bool) string {
+	switch {
+	case !ok:
+		return "    "
+	case d > 0:
+		return StyleNegative.Render(fmt.Sprintf("▲%3d", d))
+	case d < 0:
+		return StylePositive.Render(fmt.Sprintf("▼%3d", -d))
+	default:
+		return StyleMuted.Render("  ─ ")
+	}
+}
+
+// stepRiskCountry returns the country dir rows away from the one the news
+// is filtered to, in panel order, wrapping around; the first row when no
+// risk country is selected yet
+func (m Model) stepRiskCountry(dir int) string {
+	risks := m.sortedRisks()
+	if len(risks) == 0 {
+		return ""
+	}
+	for i, cr := range risks {
+		if cr.Country == m.countryFilter {
+			return risks[(i+dir+len(risks))%len(risks)].Country
+		}
+	}
+	if dir < 0 {
+		return risks[len(risks)-1].Country
+	}
+	return risks[0].Country
+}
@@ -3,7 +3,12 @@ package ui
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"watchtower/config"
+	"watchtower/intel"
+	"watchtower/markets"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -13,14 +18,16 @@ import (
 
 const (
 	stepSelectProvider = iota
+	stepModel
 	stepAPIKey
 	stepLocation
 	stepTempUnit
+	stepCryptoProfile
 	stepSaving
 	stepDone
 )
 
-var providers = []string{"groq", "openai", "deepseek", "gemini", "claude", "local"}
+var providers = []string{"groq", "openai", "deepseek", "gemini", "claude", "openrouter", "local"}
 
 var tempUnits = []string{"celsius", "fahrenheit"}
 
@@ -37,21 +44,35 @@ type SetupModel struct {
 	step                int
 	selectedIdx         int
 	tempUnitSelectedIdx int
+	profileSelectedIdx  int
 
+	modelInput   textinput.Model // pre-filled with the provider's default model
 	apiKeyInput  textinput.Model
 	cityInput    textinput.Model
 	countryInput textinput.Model
+	latInput     textinput.Model // optional: with lonInput, skips geocoding
+	lonInput     textinput.Model
+	locFocus     int // focused location input, in locationInputs order
+	locErr       string
 
 	spinner   spinner.Model
 	geocoding bool
 	saving    bool
 	err       string
 
+	// Geocoding matches offered for the user to choose between
+	candidates   []config.GeoCandidate
+	candidateIdx int
+
 	width  int
 	height int
 }
 
 func NewSetupModel() SetupModel {
+	modelInput := textinput.New()
+	modelInput.Placeholder = "model name"
+	modelInput.Focus()
+
 	apiKeyInput := textinput.New()
 	apiKeyInput.Placeholder = "Paste your API key here"
 	apiKeyInput.EchoMode = textinput.EchoPassword
@@ -66,16 +87,25 @@ func NewSetupModel() SetupModel {
 	countryInput.Placeholder = "e.g., PT"
 	countryInput.CharLimit = 2
 
+	latInput := textinput.New()
+	latInput.Placeholder = "optional, e.g. 38.7223"
+	lonInput := textinput.New()
+	lonInput.Placeholder = "optional, e.g. -9.1393"
+
 	sp := spinner.New()
-	sp.Spinner = spinner.Dot
+	// No config exists yet during setup; the env form of spinner_style still applies
+	sp.Spinner = spinnerByName(os.Getenv("WATCHTOWER_SPINNER_STYLE"))
 	sp.Style = StyleSpinner
 
 	return SetupModel{
 		step:         stepSelectProvider,
 		selectedIdx:  0,
+		modelInput:   modelInput,
 		apiKeyInput:  apiKeyInput,
 		cityInput:    cityInput,
 		countryInput: countryInput,
+		latInput:     latInput,
+		lonInput:     lonInput,
 		spinner:      sp,
 	}
 }
@@ -93,9 +123,12 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.modelInput.Width = minInt(50, msg.Width-20)
 		m.apiKeyInput.Width = minInt(50, msg.Width-20)
 		m.cityInput.Width = minInt(30, msg.Width-20)
 		m.countryInput.Width = 4
+		m.latInput.Width = 24
+		m.lonInput.Width = 24
 
 	case tea.KeyMsg:
 		switch m.step {
@@ -106,7 +139,8 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case tea.KeyDown, tea.KeyTab:
 				m.selectedIdx = (m.selectedIdx + 1) % len(providers)
 			case tea.KeyEnter:
-				m.step = stepAPIKey
+				m.modelInput.SetValue(defaultModel(providers[m.selectedIdx]))
+				m.step = stepModel
 				cmds = append(cmds, func() tea.Msg {
 					return tea.WindowSizeMsg{
 						Width:  m.width,
@@ -115,6 +149,24 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				})
 			}
 
+		case stepModel:
+			switch msg.Type {
+			case tea.KeyEnter:
+				if strings.TrimSpace(m.modelInput.Value()) != "" {
+					m.step = stepAPIKey
+				}
+				cmds = append(cmds, func() tea.Msg {
+					return tea.WindowSizeMsg{
+						Width:  m.width,
+						Height: m.height,
+					}
+				})
+			default:
+				var cmd tea.Cmd
+				m.modelInput, cmd = m.modelInput.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+
 		case stepAPIKey:
 			switch msg.Type {
 			case tea.KeyEnter:
@@ -136,7 +188,10 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case stepLocation:
 			switch msg.Type {
 			case tea.KeyEnter:
-				if m.cityInput.Value() != "" && m.countryInput.Value() != "" {
+				if _, _, _, err := m.manualCoords(); err != nil {
+					m.locErr = err.Error()
+				} else if m.cityInput.Value() != "" && m.countryInput.Value() != "" {
+					m.locErr = ""
 					m.step = stepTempUnit
 					cmds = append(cmds, func() tea.Msg {
 						return tea.WindowSizeMsg{
@@ -146,14 +201,21 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					})
 
 				}
-			case tea.KeyTab:
-				m.cityInput.Blur()
-				m.countryInput.Focus()
+			case tea.KeyTab, tea.KeyShiftTab:
+				inputs := m.locationInputs()
+				inputs[m.locFocus].Blur()
+				step := 1
+				if msg.Type == tea.KeyShiftTab {
+					step = len(inputs) - 1
+				}
+				m.locFocus = (m.locFocus + step) % len(inputs)
+				inputs[m.locFocus].Focus()
 			default:
-				var cmd1, cmd2 tea.Cmd
-				m.cityInput, cmd1 = m.cityInput.Update(msg)
-				m.countryInput, cmd2 = m.countryInput.Update(msg)
-				cmds = append(cmds, cmd1, cmd2)
+				for _, in := range m.locationInputs() {
+					var cmd tea.Cmd
+					*in, cmd = in.Update(msg)
+					cmds = append(cmds, cmd)
+				}
 			}
 
 		case stepTempUnit:
@@ -162,10 +224,32 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.tempUnitSelectedIdx = (m.tempUnitSelectedIdx - 1 + len(tempUnits)) % len(tempUnits)
 			case tea.KeyDown, tea.KeyTab:
 				m.tempUnitSelectedIdx = (m.tempUnitSelectedIdx + 1) % len(tempUnits)
+			case tea.KeyEnter:
+				m.step = stepCryptoProfile
+				cmds = append(cmds, func() tea.Msg {
+					return tea.WindowSizeMsg{
+						Width:  m.width,
+						Height: m.height,
+					}
+				})
+			}
+
+		case stepCryptoProfile:
+			n := len(markets.CryptoProfiles)
+			switch msg.Type {
+			case tea.KeyUp, tea.KeyShiftTab:
+				m.profileSelectedIdx = (m.profileSelectedIdx - 1 + n) % n
+			case tea.KeyDown, tea.KeyTab:
+				m.profileSelectedIdx = (m.profileSelectedIdx + 1) % n
 			case tea.KeyEnter:
 				m.step = stepSaving
-				m.geocoding = true
-				cmds = append(cmds, m.doGeocode())
+				if lat, lon, ok, _ := m.manualCoords(); ok {
+					m.saving = true
+					cmds = append(cmds, m.doSave(lat, lon))
+				} else {
+					m.geocoding = true
+					cmds = append(cmds, m.doGeocode())
+				}
 				cmds = append(cmds, func() tea.Msg {
 					return tea.WindowSizeMsg{
 						Width:  m.width,
@@ -175,6 +259,21 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case stepSaving:
+			if len(m.candidates) > 0 {
+				switch msg.Type {
+				case tea.KeyUp, tea.KeyShiftTab:
+					m.candidateIdx = (m.candidateIdx - 1 + len(m.candidates)) % len(m.candidates)
+				case tea.KeyDown, tea.KeyTab:
+					m.candidateIdx = (m.candidateIdx + 1) % len(m.candidates)
+				case tea.KeyEnter:
+					c := m.candidates[m.candidateIdx]
+					m.candidates = nil
+					m.useCandidate(c)
+					m.saving = true
+					cmds = append(cmds, m.doSave(c.Latitude, c.Longitude))
+				}
+				break
+			}
 			if msg.Type == tea.KeyEnter && m.err != "" {
 				m.step = stepLocation
 				m.err = ""
@@ -196,11 +295,17 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case geocodeResultMsg:
 		m.geocoding = false
-		if msg.err != nil {
+		switch {
+		case msg.err != nil:
 			m.err = msg.err.Error()
-		} else {
+		case len(msg.candidates) > 1:
+			m.candidates = msg.candidates
+			m.candidateIdx = 0
+		default:
+			c := msg.candidates[0]
+			m.useCandidate(c)
 			m.saving = true
-			cmds = append(cmds, m.doSave(msg.lat, msg.lon))
+			cmds = append(cmds, m.doSave(c.Latitude, c.Longitude))
 		}
 
 	case saveResultMsg:
@@ -220,7 +325,7 @@ func (m SetupModel) View() string {
 		return "Initializing setup..."
 	}
 
-	stepIndicator := StyleStepIndicator.Render(fmt.Sprintf("[%d/5]", m.step+1))
+	stepIndicator := StyleStepIndicator.Render(fmt.Sprintf("[%d/%d]", m.step+1, stepSaving+1))
 	header := lipgloss.JoinVertical(
 		lipgloss.Center,
 		stepIndicator,
@@ -231,12 +336,16 @@ func (m SetupModel) View() string {
 	switch m.step {
 	case stepSelectProvider:
 		content = m.renderProviderStep()
+	case stepModel:
+		content = m.renderModelStep()
 	case stepAPIKey:
 		content = m.renderAPIKeyStep()
 	case stepLocation:
 		content = m.renderLocationStep()
 	case stepTempUnit:
 		content = m.renderTempUnitStep()
+	case stepCryptoProfile:
+		content = m.renderCryptoProfileStep()
 	case stepSaving:
 		content = m.renderSavingStep()
 	case stepDone:
@@ -283,6 +392,26 @@ func (m SetupModel) renderProviderStep() string {
 	return content
 }
 
+// defaultModel is the model a provider uses when llm_model is empty
+func defaultModel(provider string) string {
+	return intel.LLMConfig{Provider: intel.Provider(provider)}.ModelName()
+}
+
+func (m SetupModel) renderModelStep() string {
+	selectedProvider := providers[m.selectedIdx]
+
+	content := StyleAccent.Render(asciiTitle) + "\n\n"
+	content += StylePrompt.Render("Selected: "+selectedProvider) + "\n\n"
+	content += "Model to use with " + StyleAccent.Render(selectedProvider) + ":\n\n"
+	content += m.modelInput.View() + "\n\n"
+	if strings.TrimSpace(m.modelInput.Value()) == "" {
+		content += StyleError.Render("Enter a model name; the default is "+defaultModel(selectedProvider)) + "\n"
+	}
+	content += StyleHint.Render("Enter keeps the default, or type another of the provider's models. Saved as llm_model.")
+
+	return content
+}
+
 func (m SetupModel) renderAPIKeyStep() string {
 	selectedProvider := providers[m.selectedIdx]
 
@@ -300,8 +429,13 @@ func (m SetupModel) renderLocationStep() string {
 	content += StylePrompt.Render("Enter your location for weather and local news:") + "\n\n"
 	content += "  City:          " + m.cityInput.View() + "\n"
 	content += "  Country code: " + m.countryInput.View() + "\n\n"
+	content += StyleMuted.Render("  Or pin exact coordinates (skips the city lookup):") + "\n"
+	content += "  Latitude:     " + m.latInput.View() + "\n"
+	content += "  Longitude:    " + m.lonInput.View() + "\n\n"
 
-	if m.err != "" {
+	if m.locErr != "" {
+		content += StyleError.Render("Error: " + m.locErr)
+	} else if m.err != "" {
 		content += StyleError.Render("Error: "+m.err) + "\n"
 		content += StyleHint.Render("Press Enter to go back and try again.")
 	} else {
@@ -311,6 +445,33 @@ func (m SetupModel) renderLocationStep() string {
 	return content
 }
 
+// locationInputs lists the location step's inputs in tab order
+func (m *SetupModel) locationInputs() []*textinput.Model {
+	return []*textinput.Model{&m.cityInput, &m.countryInput, &m.latInput, &m.lonInput}
+}
+
+// manualCoords parses the optional latitude/longitude inputs. ok is false
+// when both are blank; err is set when they are partial or out of range.
+func (m SetupModel) manualCoords() (lat, lon float64, ok bool, err error) {
+	latStr := strings.TrimSpace(m.latInput.Value())
+	lonStr := strings.TrimSpace(m.lonInput.Value())
+	if latStr == "" && lonStr == "" {
+		return 0, 0, false, nil
+	}
+	if latStr == "" || lonStr == "" {
+		return 0, 0, false, fmt.Errorf("enter both latitude and longitude, or neither")
+	}
+	lat, err = strconv.ParseFloat(latStr, 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return 0, 0, false, fmt.Errorf("latitude must be a number between -90 and 90")
+	}
+	lon, err = strconv.ParseFloat(lonStr, 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return 0, 0, false, fmt.Errorf("longitude must be a number between -180 and 180")
+	}
+	return lat, lon, true, nil
+}
+
 func (m SetupModel) renderTempUnitStep() string {
 	content := StyleAccent.Render(asciiTitle) + "\n\n"
 	content += StylePrompt.Render("Select temperature unit:") + "\n\n"
@@ -328,12 +489,43 @@ func (m SetupModel) renderTempUnitStep() string {
 	return content
 }
 
+func (m SetupModel) renderCryptoProfileStep() string {
+	content := StyleAccent.Render(asciiTitle) + "\n\n"
+	content += StylePrompt.Render("Select which crypto assets to track:") + "\n\n"
+
+	for i, p := range markets.CryptoProfiles {
+		if i == m.profileSelectedIdx {
+			content += StyleSelectedItem.Render("> "+p.Name) + "\n"
+		} else {
+			content += StyleMuted.Render("  "+p.Name) + "\n"
+		}
+	}
+
+	ids := markets.CryptoProfiles[m.profileSelectedIdx].IDs
+	content += "\n" + StyleMuted.Render(strings.Join(ids, ", ")) + "\n"
+	content += "\n" + StyleHint.Render("Saved as crypto_pairs in the config; edit it any time")
+
+	return content
+}
+
 func (m SetupModel) renderSavingStep() string {
 	var lines []string
 
 	if m.geocoding {
 		lines = append(lines, m.spinner.View()+" Looking up coordinates...")
 	}
+	if len(m.candidates) > 0 {
+		lines = append(lines, StylePrompt.Render("Several places match — which one is yours?"), "")
+		for i, c := range m.candidates {
+			if i == m.candidateIdx {
+				lines = append(lines, StyleSelectedItem.Render("> "+c.Label()))
+			} else {
+				lines = append(lines, StyleMuted.Render("  "+c.Label()))
+			}
+		}
+		lines = append(lines, "", StyleHint.Render("Use ↑↓ or tab to select, Enter to continue"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
 	if m.saving {
 		lines = append(lines, m.spinner.View()+" Saving configuration...")
 	}
@@ -351,6 +543,8 @@ func (m SetupModel) renderDoneStep() string {
 
 	msg := StyleSuccess.Render("Setup complete!") + "\n\n"
 	msg += "  Provider: " + StyleAccent.Render(provider) + "\n"
+	msg += "  Model:    " + StyleAccent.Render(strings.TrimSpace(m.modelInput.Value())) + "\n"
+	msg += "  API key:  " + StyleAccent.Render(config.MaskSecret(m.apiKeyInput.Value())) + "\n"
 	msg += "  Location: " + StyleAccent.Render(location) + "\n\n"
 	msg += StyleHint.Render("Press any key to launch Watchtower...")
 
@@ -362,8 +556,19 @@ func (m SetupModel) doGeocode() tea.Cmd {
 		ctx := context.Background()
 		city := m.cityInput.Value()
 		country := m.countryInput.Value()
-		lat, lon, err := config.Geocode(ctx, city, country)
-		return geocodeResultMsg{lat: lat, lon: lon, err: err}
+		candidates, err := config.GeocodeCandidates(ctx, city, country)
+		return geocodeResultMsg{candidates: candidates, err: err}
+	}
+}
+
+// useCandidate adopts the matched place's name and country code, so a
+// name-only fallback match doesn't keep a wrong country for local news.
+func (m *SetupModel) useCandidate(c config.GeoCandidate) {
+	if c.Name != "" {
+		m.cityInput.SetValue(c.Name)
+	}
+	if c.CountryCode != "" {
+		m.countryInput.SetValue(strings.ToUpper(c.CountryCode))
 	}
 }
 
@@ -372,6 +577,7 @@ func (m SetupModel) doSave(lat, lon float64) tea.Cmd {
 		cfg := &config.Config{
 			LLMProvider: providers[m.selectedIdx],
 			LLMAPIKey:   m.apiKeyInput.Value(),
+			LLMModel:    strings.TrimSpace(m.modelInput.Value()),
 			Location: config.Location{
 				City:      m.cityInput.Value(),
 				Country:   m.countryInput.Value(),
@@ -381,7 +587,7 @@ func (m SetupModel) doSave(lat, lon float64) tea.Cmd {
 			TempUnit:       tempUnits[m.tempUnitSelectedIdx],
 			RefreshSec:     120,
 			BriefCacheMins: 60,
-			CryptoPairs:    []string{"bitcoin", "ethereum", "dogecoin", "usd-coin"},
+			CryptoPairs:    markets.CryptoProfiles[m.profileSelectedIdx].IDs,
 		}
 		err := config.Save(cfg)
 		return saveResultMsg{err: err}
@@ -389,9 +595,8 @@ func (m SetupModel) doSave(lat, lon float64) tea.Cmd {
 }
 
 type geocodeResultMsg struct {
-	lat float64
-	lon float64
-	err error
+	candidates []config.GeoCandidate
+	err        error
 }
 
 type saveResultMsg struct {
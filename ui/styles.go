@@ -1,6 +1,12 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"hash/fnv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Color palette — dark terminal friendly
 var (
@@ -16,6 +22,8 @@ var (
 	colorWhite  = lipgloss.Color("#e6edf3")
 	colorPurple = lipgloss.Color("#bc8cff")
 	colorTeal   = lipgloss.Color("#39d353")
+	colorSubtle = lipgloss.Color("#c9d1d9")
+	colorDim    = lipgloss.Color("#6e7681")
 
 	// Backgrounds for badges
 	bgCritical = lipgloss.Color("#b91c1c")
@@ -81,9 +89,30 @@ var (
 			Foreground(colorAccent).
 			Bold(true)
 
+	// Source tag for items from the user's webhook alerts feed
+	StyleAlertSource = lipgloss.NewStyle().
+				Foreground(colorBg).
+				Background(colorYellow).
+				Bold(true)
+
 	StyleAge = lipgloss.NewStyle().
 			Foreground(colorMuted)
 
+	// Age gradient — brightest for breaking items, fading as they get older
+	StyleAgeFresh = lipgloss.NewStyle().
+			Foreground(colorWhite).
+			Bold(true)
+
+	StyleAgeRecent = lipgloss.NewStyle().
+			Foreground(colorSubtle)
+
+	StyleAgeStale = lipgloss.NewStyle().
+			Foreground(colorDim)
+
+	StylePinned = lipgloss.NewStyle().
+			Foreground(colorGold).
+			Bold(true)
+
 	StyleSymbol = lipgloss.NewStyle().
 			Foreground(colorGold).
 			Bold(true)
@@ -97,6 +126,17 @@ var (
 	StyleNegative = lipgloss.NewStyle().
 			Foreground(colorRed)
 
+	// Moves beyond market_change_strong
+	StylePositiveStrong = lipgloss.NewStyle().
+				Foreground(colorGreen).
+				Bold(true).
+				Underline(true)
+
+	StyleNegativeStrong = lipgloss.NewStyle().
+				Foreground(colorRed).
+				Bold(true).
+				Underline(true)
+
 	StyleNeutral = lipgloss.NewStyle().
 			Foreground(colorMuted)
 
@@ -116,6 +156,14 @@ var (
 			Background(bgCritical).
 			Bold(true)
 
+	// Very recent critical items; reverse + blink where the terminal supports it
+	StyleBreaking = lipgloss.NewStyle().
+			Foreground(colorWhite).
+			Background(bgCritical).
+			Bold(true).
+			Reverse(true).
+			Blink(true)
+
 	StyleHighThreat = lipgloss.NewStyle().
 			Foreground(colorWhite).
 			Background(bgHigh).
@@ -157,6 +205,11 @@ var (
 				Foreground(colorAccent).
 				Bold(true)
 
+	// Inline summary under an expanded article
+	StyleDescription = lipgloss.NewStyle().
+				Foreground(colorSubtle).
+				Italic(true)
+
 	// Footer with status message
 	StyleFooterStatus = lipgloss.NewStyle().
 				Foreground(colorGreen).
@@ -226,3 +279,96 @@ var (
 			Foreground(colorGreen).
 			Bold(true)
 )
+
+// sourcePalette holds the source tag colors; each source hashes to one,
+// so it keeps its color across runs
+var sourcePalette = []lipgloss.Color{
+	"#58a6ff", "#3fb950", "#d29922", "#bc8cff", "#db6d28",
+	"#39c5cf", "#f778ba", "#e3b341", "#a5d6ff", "#7ee787",
+}
+
+// sourceColor returns the stable tag color for a source name
+func sourceColor(name string) lipgloss.Color {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(name)))
+	return sourcePalette[h.Sum32()%uint32(len(sourcePalette))]
+}
+
+// sourceAbbrev shortens a source name to at most three letters: a leading
+// acronym ("BBC World" → BBC), the initials of several words ("Al Jazeera"
+// → AJ) or the start of a single word ("Reuters" → REU).
+func sourceAbbrev(name string) string {
+	words := strings.Fields(name)
+	if len(words) > 1 && strings.EqualFold(words[0], "the") {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return "?"
+	}
+	first := []rune(words[0])
+	if len(first) >= 2 && len(first) <= 3 && strings.ToUpper(words[0]) == words[0] {
+		return words[0]
+	}
+	if len(words) == 1 {
+		return strings.ToUpper(string(first[:min(3, len(first))]))
+	}
+	var initials []rune
+	for _, w := range words[:min(3, len(words))] {
+		initials = append(initials, []rune(strings.ToUpper(w))[0])
+	}
+	return string(initials)
+}
+
+// SetAccent recolors every accent-colored style (titles, sources, active
+// tab, spinner…). It runs once at startup, before anything is rendered.
+func SetAccent(c lipgloss.Color) {
+	colorAccent = c
+	for _, s := range []*lipgloss.Style{
+		&StyleTitle, &StyleActiveTab, &StyleSectionHeader, &StyleSource,
+		&StyleSpinner, &StyleSelectedTitle, &StyleQuadrantTitle, &StyleWeatherDesc,
+		&StyleSetupTitle, &StyleSelectedItem, &StyleAccent,
+	} {
+		*s = s.Foreground(c)
+	}
+}
+
+// spinnerStyles maps spinner_style names to the bubbles presets
+var spinnerStyles = map[string]spinner.Spinner{
+	"line":      spinner.Line,
+	"dot":       spinner.Dot,
+	"minidot":   spinner.MiniDot,
+	"jump":      spinner.Jump,
+	"pulse":     spinner.Pulse,
+	"points":    spinner.Points,
+	"globe":     spinner.Globe,
+	"moon":      spinner.Moon,
+	"monkey":    spinner.Monkey,
+	"meter":     spinner.Meter,
+	"hamburger": spinner.Hamburger,
+	"ellipsis":  spinner.Ellipsis,
+}
+
+// spinnerByName returns the named spinner preset, falling back to Dot for
+// empty or unknown names.
+func spinnerByName(name string) spinner.Spinner {
+	if sp, ok := spinnerStyles[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return sp
+	}
+	return spinner.Dot
+}
+
+// SpinnerStyleValid reports whether name is a known spinner_style
+func SpinnerStyleValid(name string) bool {
+	_, ok := spinnerStyles[strings.ToLower(strings.TrimSpace(name))]
+	return ok
+}
+
+// progressBar renders done/total as a fixed-width bar, e.g. "█████░░░"
+func progressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return StyleAccent.Render(strings.Repeat("█", filled)) +
+		StyleMuted.Render(strings.Repeat("░", width-filled))
+}
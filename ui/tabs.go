@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"watchtower/feeds"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Tab positions in Model.tabs. Data handlers use these to know which tab to
+// re-render; everything else (tab bar, shortcuts, viewports, footer) is
+// driven by the tabs slice itself.
+const (
+	TabOverview = iota
+	TabNews
+	TabLocal
+	TabMarkets
+)
+
+// tabDef describes one dashboard tab. Adding a tab means adding an entry to
+// newTabs; numeric shortcuts map to positions automatically.
+type tabDef struct {
+	name     string
+	sections []string // m.loading keys whose spinners animate on this tab
+
+	// render returns the tab content and, for list tabs, the number of
+	// lines above the first list row (used for scroll tracking).
+	render func(m Model) (string, int)
+
+	list *tabList             // nil when the tab has no selectable list
+	hint func(m Model) string // footer key hint
+}
+
+// tabList is the selectable list of a tab: articles, or other rows such as
+// prediction markets.
+type tabList struct {
+	items    func(m Model) []feeds.NewsItem // articles; nil for non-article lists
+	count    func(m Model) int              // row count; nil = len(items)
+	selected func(m *Model) *int
+	rowLines int // lines each row takes, for scrolling
+}
+
+// size returns the number of selectable rows
+func (l *tabList) size(m Model) int {
+	if l.count != nil {
+		return l.count(m)
+	}
+	return len(l.items(m))
+}
+
+func newTabs() []tabDef {
+	return []tabDef{
+		TabOverview: {
+			name:     "Overview",
+			sections: []string{"weather", "brief", "briefRefresh", "crypto", "stocks", "commodities", "poly"},
+			render:   func(m Model) (string, int) { return m.renderOverviewContent(), 0 },
+			hint: func(m Model) string {
+				return "↑↓/jk scroll  tab/←→ switch  " + m.tabKeysHint() + "  r refresh  b brief  y copy brief  F news on top country  s snapshot  f focus  w glance  q quit"
+			},
+		},
+		TabNews: {
+			name:     "Global News",
+			sections: []string{"global", "alerts", "brief", "risks"},
+			render:   Model.renderNewsContent,
+			list: &tabList{
+				items:    func(m Model) []feeds.NewsItem { return m.newsListItems() },
+				selected: func(m *Model) *int { return &m.selectedNewsIdx },
+				rowLines: 3,
+			},
+			hint: func(m Model) string {
+				return "jk navigate  " + m.articleKeysHint() + "  d/u page  g/G top/bottom  tab switch  r refresh  b brief  m compare sources  F top country filter  [/] risk country  O sort risks  C re-score risks  R risk panel  f focus  q quit"
+			},
+		},
+		TabLocal: {
+			name:     "Local",
+			sections: []string{"local", "weather", "localBrief", "safetyBrief"},
+			render:   Model.renderLocalContent,
+			list: &tabList{
+				items:    func(m Model) []feeds.NewsItem { return m.localItems() },
+				selected: func(m *Model) *int { return &m.selectedLocalNewsIdx },
+				rowLines: 3,
+			},
+			hint: func(m Model) string {
+				return "jk navigate  " + m.articleKeysHint() + "  d/u page  g/G top/bottom  tab switch  r refresh  i local brief  S summary/safety  f focus  q quit"
+			},
+		},
+		TabMarkets: {
+			name:     "Markets",
+			sections: []string{"crypto", "stocks", "commodities", "poly"},
+			render:   Model.renderMarketsContent,
+			list: &tabList{
+				count:    func(m Model) int { return len(m.quoteRows()) + len(m.polyMarkets) },
+				selected: func(m *Model) *int { return &m.selectedMarketIdx },
+				rowLines: 1,
+			},
+			hint: func(m Model) string {
+				return "jk select  enter/o open on Yahoo Finance / Polymarket  d/u page  g/G top/bottom  tab switch  r refresh  s snapshot  f focus  q quit"
+			},
+		},
+	}
+}
+
+// switchTab makes tab active and forces a redraw
+func (m *Model) switchTab(tab int) tea.Cmd {
+	n := len(m.tabs)
+	m.activeTab = (tab%n + n) % n
+	if m.activeTab == TabOverview && m.cfg.BriefTrigger == "overview" {
+		return tea.Batch(m.redraw(), m.autoBrief())
+	}
+	return m.redraw()
+}
+
+// tabKeysHint lists the numeric shortcuts, e.g. "1 overview  2 global news"
+func (m Model) tabKeysHint() string {
+	var parts []string
+	for i, t := range m.tabs {
+		if i >= 9 {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", i+1, strings.ToLower(t.name)))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// activeList returns the article list of the active tab, or nil
+func (m Model) activeList() *tabList {
+	return m.tabs[m.activeTab].list
+}
+
+// moveSelection sets the active list's selection to idx (clamped), re-renders
+// and scrolls it into view.
+func (m *Model) moveSelection(idx int) tea.Cmd {
+	l := m.activeList()
+	sel := l.selected(m)
+	*sel = maxInt(minInt(idx, l.size(*m)-1), 0)
+	m.rerender(m.activeTab)
+	scrollNewsIntoView(&m.viewports[m.activeTab], m.headerLines[m.activeTab], *sel, l.rowLines)
+	return m.redraw()
+}
+
+// redraw forces a full repaint by replaying the current window size
+func (m Model) redraw() tea.Cmd {
+	return func() tea.Msg {
+		return tea.WindowSizeMsg{
+			Width:  m.termWidth,
+			Height: m.height,
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Each refresh interval runs as a chain of one-shot timers re-armed by the
+// tickMsg handler. If a tick is ever lost (a suspended process, a message
+// dropped while the program was busy) the chain ends and that section goes
+// stale silently. The watchdog notices an interval that hasn't fired for a
+// few periods and starts a fresh chain.
+
+// Timers don't run while the machine sleeps, so after a resume every chain
+// is late by the time spent asleep and sections on long intervals would
+// show hours-old data until their next tick. Comparing wall-clock times
+// of a timer's consecutive firings catches that (the monotonic clock
+// stops during suspend, the wall clock doesn't), and all sections are
+// refreshed at once.
+
+// stallFactor is how many missed periods mark a refresh timer as stuck
+const stallFactor = 3
+
+// watchdogEvery is how often the watchdog checks the timers while the
+// spinner is idle; while it spins the check also runs on each spinner tick.
+const watchdogEvery = 30 * time.Second
+
+// refreshTimer tracks one interval's tick chain
+type refreshTimer struct {
+	last time.Time // when the chain last fired (or was armed)
+	gen  int       // bumped on restart; ticks from older chains are dropped
+}
+
+type watchdogMsg time.Time
+
+func watchdogTick() tea.Cmd {
+	return tea.Tick(watchdogEvery, func(t time.Time) tea.Msg { return watchdogMsg(t) })
+}
+
+// newRefreshTimers returns one timer per refresh interval, armed at now
+func newRefreshTimers(intervals []time.Duration, now time.Time) map[time.Duration]*refreshTimer {
+	timers := make(map[time.Duration]*refreshTimer, len(intervals))
+	for _, d := range intervals {
+		timers[d] = &refreshTimer{last: now}
+	}
+	return timers
+}
+
+// resumed reports whether a timer due every expected that last fired at
+// prev and fires again at now is late by more than resume_gap_seconds of
+// wall-clock time, i.e. the machine slept in between
+func (m Model) resumed(prev, now time.Time, expected time.Duration) bool {
+	if m.cfg.ResumeGapSec < 0 || prev.IsZero() {
+		return false
+	}
+	late := now.Round(0).Sub(prev.Round(0)) - expected
+	return late > time.Duration(m.cfg.ResumeGapSec)*time.Second
+}
+
+// restartTimers re-arms every refresh timer from now and refreshes all
+// sections, for after a resume from sleep
+func (m *Model) restartTimers(now time.Time) tea.Cmd {
+	var cmds []tea.Cmd
+	for d, t := range m.timers {
+		t.gen++
+		t.last = now
+		cmds = append(cmds, tickEvery(d, t.gen))
+	}
+	if !m.cfg.QuietHours.Active(now) {
+		m.lastRefresh = time.Time{}
+		m.statusMsg = "Resumed from sleep, refreshing..."
+		m.statusExpiry = now.Add(3 * time.Second)
+		cmds = append(cmds, m.startRefreshAll())
+	}
+	return tea.Batch(cmds...)
+}
+
+// checkTimers re-arms every refresh timer that has missed stallFactor
+// periods and refreshes its sections right away. Healthy timers are left
+// alone, so it returns nil in the normal case.
+func (m Model) checkTimers(now time.Time) tea.Cmd {
+	var cmds []tea.Cmd
+	for d, t := range m.timers {
+		if now.Sub(t.last) <= stallFactor*d {
+			continue
+		}
+		t.gen++
+		t.last = now
+		cmds = append(cmds, tickEvery(d, t.gen))
+		if !m.cfg.QuietHours.Active(now) {
+			cmds = append(cmds, m.startRefresh(sectionsEvery(m.cfg, d)...))
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Batch(cmds...)
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"strings"
 	"time"
+
+	"watchtower/fixtures"
 )
 
 // Conditions holds current weather data
@@ -26,23 +30,84 @@ type Conditions struct {
 
 // DayForecast holds a single day's forecast
 type DayForecast struct {
-	Date     time.Time
-	MaxTempC float64
-	MinTempC float64
-	RainMM   float64
-	Icon     string
-	Desc     string
+	Date       time.Time
+	MaxTempC   float64
+	MinTempC   float64
+	RainMM     float64
+	RainChance int // max precipitation probability, %
+	Icon       string
+	Desc       string
+	Sunrise    string // local wall time "HH:MM"; "" if unknown (e.g. polar day)
+	Sunset     string
 }
 
 var httpClient = &http.Client{Timeout: 10 * time.Second}
 
-// Fetch retrieves current weather and 5-day forecast using Open-Meteo
-func Fetch(ctx context.Context, lat, lon float64, city string) (*Conditions, []DayForecast, error) {
+// Provider is a source of current conditions and a daily forecast. Fields a
+// provider doesn't report (UV, visibility, sunrise…) are left zero.
+type Provider interface {
+	Name() string
+	Fetch(ctx context.Context, lat, lon float64, city string) (*Conditions, []DayForecast, error)
+}
+
+// providers lists the available providers by config name, in fallback order
+var providers = []Provider{openMeteo{}, wttr{}}
+
+// ProviderValid reports whether name is a known weather_provider
+func ProviderValid(name string) bool {
+	for _, p := range providers {
+		if p.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Fetch retrieves current weather and the daily forecast from primary
+// ("open-meteo" or "wttr"; "" = open-meteo), falling back to the other
+// providers in order if it fails.
+func Fetch(ctx context.Context, lat, lon float64, city, primary string) (*Conditions, []DayForecast, error) {
+	if cond, forecast, ok, err := fixtureWeather(city); ok {
+		return cond, forecast, err
+	}
+	order := make([]Provider, 0, len(providers))
+	for _, p := range providers {
+		if p.Name() == primary {
+			order = append(order, p)
+		}
+	}
+	for _, p := range providers {
+		if p.Name() != primary {
+			order = append(order, p)
+		}
+	}
+
+	var errs []string
+	for _, p := range order {
+		cond, forecast, err := p.Fetch(ctx, lat, lon, city)
+		if err == nil {
+			return cond, forecast, nil
+		}
+		errs = append(errs, err.Error())
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, nil, fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+// ─── Open-Meteo ───────────────────────────────────────────────────────────────
+
+type openMeteo struct{}
+
+func (openMeteo) Name() string { return "open-meteo" }
+
+func (openMeteo) Fetch(ctx context.Context, lat, lon float64, city string) (*Conditions, []DayForecast, error) {
 	url := fmt.Sprintf(
 		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f"+
 			"&current=temperature_2m,relative_humidity_2m,apparent_temperature,is_day,"+
 			"weather_code,wind_speed_10m,wind_direction_10m,uv_index,visibility"+
-			"&daily=weather_code,temperature_2m_max,temperature_2m_min,precipitation_sum"+
+			"&daily=weather_code,temperature_2m_max,temperature_2m_min,precipitation_sum,precipitation_probability_max,sunrise,sunset"+
 			"&timezone=auto&forecast_days=10",
 		lat, lon,
 	)
@@ -80,6 +145,9 @@ func Fetch(ctx context.Context, lat, lon float64, city string) (*Conditions, []D
 			Temperature2mMax []float64 `json:"temperature_2m_max"`
 			Temperature2mMin []float64 `json:"temperature_2m_min"`
 			PrecipitationSum []float64 `json:"precipitation_sum"`
+			PrecipProbMax    []int     `json:"precipitation_probability_max"`
+			Sunrise          []string  `json:"sunrise"`
+			Sunset           []string  `json:"sunset"`
 		} `json:"daily"`
 	}
 
@@ -119,19 +187,103 @@ func Fetch(ctx context.Context, lat, lon float64, city string) (*Conditions, []D
 		if i < len(raw.Daily.PrecipitationSum) {
 			rain = raw.Daily.PrecipitationSum[i]
 		}
+		chance := 0
+		if i < len(raw.Daily.PrecipProbMax) {
+			chance = raw.Daily.PrecipProbMax[i]
+		}
 		forecasts = append(forecasts, DayForecast{
-			Date:     t,
-			MaxTempC: raw.Daily.Temperature2mMax[i],
-			MinTempC: raw.Daily.Temperature2mMin[i],
-			RainMM:   rain,
-			Icon:     ico,
-			Desc:     dsc,
+			Date:       t,
+			MaxTempC:   raw.Daily.Temperature2mMax[i],
+			MinTempC:   raw.Daily.Temperature2mMin[i],
+			RainMM:     rain,
+			RainChance: chance,
+			Icon:       ico,
+			Desc:       dsc,
+			Sunrise:    clockAt(raw.Daily.Sunrise, i),
+			Sunset:     clockAt(raw.Daily.Sunset, i),
 		})
 	}
 
 	return conditions, forecasts, nil
 }
 
+// clockAt returns the "HH:MM" part of the i-th Open-Meteo local timestamp
+// ("2024-05-01T05:32"), or "" if missing.
+func clockAt(times []string, i int) string {
+	if i >= len(times) {
+		return ""
+	}
+	t, err := time.Parse("2006-01-02T15:04", times[i])
+	if err != nil {
+		return ""
+	}
+	return t.Format("15:04")
+}
+
+// synodicMonth is the mean time between new moons, in days
+const synodicMonth = 29.530588853
+
+// knownNewMoon is a reference new moon (2000-01-06 18:14 UTC)
+var knownNewMoon = time.Date(2000, 1, 6, 18, 14, 0, 0, time.UTC)
+
+var moonPhases = []struct{ icon, name string }{
+	{"🌑", "New moon"},
+	{"🌒", "Waxing crescent"},
+	{"🌓", "First quarter"},
+	{"🌔", "Waxing gibbous"},
+	{"🌕", "Full moon"},
+	{"🌖", "Waning gibbous"},
+	{"🌗", "Last quarter"},
+	{"🌘", "Waning crescent"},
+}
+
+// MoonPhase returns the moon phase glyph and name at t, computed from the
+// mean lunar cycle. It can be off by up to a day near phase boundaries,
+// which is plenty for a weather panel.
+func MoonPhase(t time.Time) (icon, name string) {
+	days := t.Sub(knownNewMoon).Hours() / 24
+	age := math.Mod(days, synodicMonth)
+	if age < 0 {
+		age += synodicMonth
+	}
+	idx := int(math.Round(age/synodicMonth*8)) % 8
+	return moonPhases[idx].icon, moonPhases[idx].name
+}
+
+// Advice returns a short what-to-wear hint from the feels-like temperature,
+// wind, UV and today's rain outlook, or "" when nothing is worth flagging.
+// today may be nil if the forecast is unavailable.
+func Advice(c *Conditions, today *DayForecast) string {
+	if c == nil {
+		return ""
+	}
+	var hints []string
+
+	feels := c.FeelsLikeC
+	switch {
+	case feels <= -10:
+		hints = append(hints, "🥶 bitter cold — full winter gear")
+	case feels < 5 && c.WindSpeedKmh >= 25:
+		hints = append(hints, "🧥 cold & windy — layer up")
+	case feels < 5:
+		hints = append(hints, "🧥 cold — wear a warm coat")
+	case feels < 12:
+		hints = append(hints, "🧣 cool — bring a jacket")
+	case feels >= 35:
+		hints = append(hints, "🥵 extreme heat — stay hydrated, avoid midday sun")
+	case feels >= 28:
+		hints = append(hints, "🩳 hot — dress light, drink water")
+	}
+
+	if today != nil && (today.RainChance >= 50 || today.RainMM >= 1) {
+		hints = append(hints, "🌂 bring an umbrella")
+	} else if c.UVIndex >= 6 && c.IsDay {
+		hints = append(hints, "🧴 high UV — wear sunscreen")
+	}
+
+	return strings.Join(hints, " · ")
+}
+
 // WindDirectionStr converts degrees to compass direction
 func WindDirectionStr(deg int) string {
 	dirs := []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
@@ -142,6 +294,56 @@ func WindDirectionStr(deg int) string {
 	return dirs[idx]
 }
 
+// fixtureWeather serves the weather fixture when fixture mode is on.
+// Forecast days without a date count from today, so canned data stays current.
+func fixtureWeather(city string) (*Conditions, []DayForecast, bool, error) {
+	var canned struct {
+		Conditions *Conditions
+		Forecast   []DayForecast
+	}
+	ok, err := fixtures.Load("weather", &canned)
+	if !ok || err != nil {
+		return nil, nil, ok, err
+	}
+	if canned.Conditions == nil {
+		return nil, nil, true, fmt.Errorf("fixture weather: no Conditions")
+	}
+	now := time.Now()
+	if canned.Conditions.City == "" {
+		canned.Conditions.City = city
+	}
+	canned.Conditions.UpdatedAt = now
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for i := range canned.Forecast {
+		if canned.Forecast[i].Date.IsZero() {
+			canned.Forecast[i].Date = today.AddDate(0, 0, i)
+		}
+	}
+	return canned.Conditions, canned.Forecast, true, nil
+}
+
+// FormatWind renders a km/h wind speed in unit: kmh, mph, ms or knots.
+// Unknown units fall back to km/h.
+func FormatWind(kmh float64, unit string) string {
+	switch unit {
+	case "mph":
+		return fmt.Sprintf("%.0f mph", kmh/1.609344)
+	case "ms":
+		return fmt.Sprintf("%.0f m/s", kmh/3.6)
+	case "knots":
+		return fmt.Sprintf("%.0f kn", kmh/1.852)
+	}
+	return fmt.Sprintf("%.0f km/h", kmh)
+}
+
+// FormatVisibility renders a visibility in metres in unit: km or mi
+func FormatVisibility(metres float64, unit string) string {
+	if unit == "mi" {
+		return fmt.Sprintf("%.0f mi", metres/1609.344)
+	}
+	return fmt.Sprintf("%.0f km", metres/1000)
+}
+
 // wmoCodeToEmoji maps WMO weather codes to emoji + description
 func wmoCodeToEmoji(code int, isDay bool) (string, string) {
 	switch {
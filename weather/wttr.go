@@ -0,0 +1,175 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ─── wttr.in ──────────────────────────────────────────────────────────────────
+// wttr.in serves World Weather Online data as JSON with no key:
+//   curl "https://wttr.in/51.5074,-0.1278?format=j1"
+// Every number comes back as a string, times are 12-hour ("06:12 AM") and
+// the forecast covers three days.
+
+type wttr struct{}
+
+func (wttr) Name() string { return "wttr" }
+
+func (wttr) Fetch(ctx context.Context, lat, lon float64, city string) (*Conditions, []DayForecast, error) {
+	url := fmt.Sprintf("https://wttr.in/%.4f,%.4f?format=j1", lat, lon)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("User-Agent", "watchtower/1.0")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("wttr request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, nil, fmt.Errorf("wttr HTTP %d", resp.StatusCode)
+	}
+
+	var raw struct {
+		CurrentCondition []struct {
+			TempC         string `json:"temp_C"`
+			FeelsLikeC    string `json:"FeelsLikeC"`
+			Humidity      string `json:"humidity"`
+			WindspeedKmph string `json:"windspeedKmph"`
+			WinddirDegree string `json:"winddirDegree"`
+			Visibility    string `json:"visibility"` // km
+			UVIndex       string `json:"uvIndex"`
+			WeatherCode   string `json:"weatherCode"`
+			LocalObsTime  string `json:"localObsDateTime"` // "2024-05-01 10:15 AM"
+		} `json:"current_condition"`
+		Weather []struct {
+			Date      string `json:"date"`
+			MaxTempC  string `json:"maxtempC"`
+			MinTempC  string `json:"mintempC"`
+			Astronomy []struct {
+				Sunrise string `json:"sunrise"`
+				Sunset  string `json:"sunset"`
+			} `json:"astronomy"`
+			Hourly []struct {
+				PrecipMM     string `json:"precipMM"`
+				ChanceOfRain string `json:"chanceofrain"`
+				WeatherCode  string `json:"weatherCode"`
+			} `json:"hourly"`
+		} `json:"weather"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, nil, fmt.Errorf("decoding wttr: %w", err)
+	}
+	if len(raw.CurrentCondition) == 0 {
+		return nil, nil, fmt.Errorf("wttr returned no current conditions")
+	}
+
+	var forecasts []DayForecast
+	for _, d := range raw.Weather {
+		t, err := time.Parse("2006-01-02", d.Date)
+		if err != nil {
+			continue
+		}
+		f := DayForecast{
+			Date:     t,
+			MaxTempC: num(d.MaxTempC),
+			MinTempC: num(d.MinTempC),
+		}
+		// The day's weather is its worst hour; rain is the hourly total
+		worst := 0
+		for _, h := range d.Hourly {
+			f.RainMM += num(h.PrecipMM)
+			f.RainChance = max(f.RainChance, int(num(h.ChanceOfRain)))
+			if code := wwoToWMO(int(num(h.WeatherCode))); code > worst {
+				worst = code
+			}
+		}
+		f.Icon, f.Desc = wmoCodeToEmoji(worst, true)
+		if len(d.Astronomy) > 0 {
+			f.Sunrise = clock12(d.Astronomy[0].Sunrise)
+			f.Sunset = clock12(d.Astronomy[0].Sunset)
+		}
+		forecasts = append(forecasts, f)
+	}
+
+	c := raw.CurrentCondition[0]
+	isDay := true
+	if obs := clock12(lastField(c.LocalObsTime)); obs != "" && len(forecasts) > 0 && forecasts[0].Sunrise != "" {
+		isDay = obs >= forecasts[0].Sunrise && obs < forecasts[0].Sunset
+	}
+	icon, desc := wmoCodeToEmoji(wwoToWMO(int(num(c.WeatherCode))), isDay)
+
+	conditions := &Conditions{
+		City:          city,
+		TempC:         num(c.TempC),
+		FeelsLikeC:    num(c.FeelsLikeC),
+		Humidity:      int(num(c.Humidity)),
+		WindSpeedKmh:  num(c.WindspeedKmph),
+		WindDirection: int(num(c.WinddirDegree)),
+		Description:   desc,
+		Icon:          icon,
+		Visibility:    num(c.Visibility) * 1000,
+		UVIndex:       num(c.UVIndex),
+		IsDay:         isDay,
+		UpdatedAt:     time.Now(),
+	}
+	return conditions, forecasts, nil
+}
+
+// num parses one of wttr's numeric strings; malformed values read as 0
+func num(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+// clock12 converts wttr's "06:12 AM" to "06:12"; "" if unparseable (wttr
+// reports "No sunrise" during polar night)
+func clock12(s string) string {
+	t, err := time.Parse("03:04 PM", s)
+	if err != nil {
+		return ""
+	}
+	return t.Format("15:04")
+}
+
+// lastField returns the time part of "2024-05-01 10:15 AM"
+func lastField(s string) string {
+	if len(s) < 8 {
+		return ""
+	}
+	return s[len(s)-8:]
+}
+
+// wwoToWMO maps a World Weather Online condition code to the nearest WMO
+// code, so both providers share wmoCodeToEmoji and its translations
+func wwoToWMO(code int) int {
+	switch code {
+	case 113:
+		return 0
+	case 116:
+		return 2
+	case 119, 122:
+		return 3
+	case 143, 248, 260:
+		return 45
+	case 263, 266, 281, 284:
+		return 51
+	case 176, 293, 296, 353:
+		return 80
+	case 299, 302, 305, 308, 311, 314, 356, 359:
+		return 63
+	case 179, 182, 185, 227, 230, 317, 320, 323, 326, 329, 332, 335, 338, 350, 362, 365, 368, 371, 374, 377, 392, 395:
+		return 73
+	case 200, 386, 389:
+		return 95
+	}
+	return 3
+}